@@ -0,0 +1,254 @@
+package color
+
+import (
+	"sort"
+	"strings"
+)
+
+// Attribute is a text attribute, such as bold or underlined text, that can
+// be combined with paints.
+type Attribute string
+
+// Standard text attributes. Not all terminals support all of them.
+const (
+	Bold          Attribute = `1`
+	Dim           Attribute = `2`
+	Italic        Attribute = `3`
+	Underline     Attribute = `4`
+	Blink         Attribute = `5`
+	Reverse       Attribute = `7`
+	Strikethrough Attribute = `9`
+)
+
+// Styled underlines, used by editors and rich command line tools to mark
+// diagnostics.  They are an extension from kitty, also understood by iTerm2,
+// WezTerm and VTE based terminals.  Other terminals usually fall back to a
+// plain underline, but a few old ones ignore the whole sequence.
+const (
+	CurlyUnderline  Attribute = `4:3`
+	DottedUnderline Attribute = `4:4`
+	DashedUnderline Attribute = `4:5`
+)
+
+// Conceal hides text, such as passwords being typed.  Some terminals still
+// show it.
+const Conceal Attribute = `8`
+
+// RapidBlink makes text blink faster than Blink.  Few terminals tell them
+// apart: most blink at the same rate for both, or don't blink at all.
+const RapidBlink Attribute = `6`
+
+// underlines are all the attributes underlining text.
+var underlines = []Attribute{Underline, CurlyUnderline, DottedUnderline, DashedUnderline}
+
+// WithAttributes copies the current style and return a new Style that also
+// has the given attributes, i.e:
+//
+//	warn := color.NewStyle("", color.YellowPaint).WithAttributes(color.Bold, color.Underline)
+//
+// Attributes accumulate over calls, the ones the style already has are kept.
+// The original Style is unchanged and you must capture the return value.
+func (s Style) WithAttributes(attrs ...Attribute) Style {
+	newS := s
+	newS.attrs = addAttributes(s.attrs, attrs...)
+	newS.code, newS.csi = computeColorCode(newS.bg, newS.fg, newS.params())
+	return newS
+}
+
+// Attributes gives the attributes of the style, sorted by their SGR code.
+func (s Style) Attributes() []Attribute {
+	var attrs []Attribute
+	for _, a := range splitAttributes(s.attrs) {
+		attrs = append(attrs, Attribute(a))
+	}
+	return attrs
+}
+
+// addAttributes adds attrs to a set of attributes, kept as sorted and
+// unique SGR parameters joined by semicolons.
+func addAttributes(set string, attrs ...Attribute) string {
+	all := splitAttributes(set)
+	for _, a := range attrs {
+		if a != "" && !hasAttribute(all, a) {
+			all = append(all, string(a))
+		}
+	}
+	sort.Strings(all)
+	return strings.Join(all, ";")
+}
+
+// removeAttributes removes attrs from a set of attributes.
+func removeAttributes(set string, attrs ...Attribute) string {
+	var kept []string
+	for _, a := range splitAttributes(set) {
+		remove := false
+		for _, r := range attrs {
+			if a == string(r) {
+				remove = true
+			}
+		}
+		if !remove {
+			kept = append(kept, a)
+		}
+	}
+	return strings.Join(kept, ";")
+}
+
+func splitAttributes(set string) []string {
+	if set == "" {
+		return nil
+	}
+	return strings.Split(set, ";")
+}
+
+func hasAttribute(set []string, a Attribute) bool {
+	for _, s := range set {
+		if s == string(a) {
+			return true
+		}
+	}
+	return false
+}
+
+var attributeNames = map[Attribute]string{
+	Bold:          "bold",
+	Dim:           "dim",
+	Italic:        "italic",
+	Underline:     "underline",
+	Blink:         "blink",
+	RapidBlink:    "rapid blink",
+	Reverse:       "reverse",
+	Conceal:       "conceal",
+	Strikethrough: "strikethrough",
+
+	CurlyUnderline:  "curly underline",
+	DottedUnderline: "dotted underline",
+	DashedUnderline: "dashed underline",
+}
+
+// attributeOffs are the SGR codes turning off each attribute.
+var attributeOffs = map[Attribute]string{
+	Bold:          "22",
+	Dim:           "22",
+	Italic:        "23",
+	Underline:     "24",
+	Blink:         "25",
+	RapidBlink:    "25",
+	Reverse:       "27",
+	Conceal:       "28",
+	Strikethrough: "29",
+
+	CurlyUnderline:  "24",
+	DottedUnderline: "24",
+	DashedUnderline: "24",
+}
+
+// Off gives the sequence turning off only what the style sets, such as `39`
+// for its foreground or `22` for bold text, instead of a full reset.  This
+// lets you end a style nested in another one without clearing the outer
+// one.  It is empty when colors are disabled or the style sets nothing.
+func (s Style) Off() string {
+	if !Enabled() {
+		return ""
+	}
+
+	offs := s.attributeOffs()
+	if s.fg != "" {
+		offs = append(offs, "39")
+	}
+	if s.bg != "" {
+		offs = append(offs, "49")
+	}
+
+	if len(offs) == 0 {
+		return ""
+	}
+	return sgr(strings.Join(offs, ";"))
+}
+
+// attributeOffs gives the sorted SGR codes turning off the attributes of
+// the style, including the bold of the bright paint constants.
+func (s Style) attributeOffs() []string {
+	attrs := s.Attributes()
+	if strings.HasPrefix(string(s.fg), "1;") {
+		// the bright paints are bold
+		attrs = append(attrs, Bold)
+	}
+	var offs []string
+	for _, a := range attrs {
+		off, ok := attributeOffs[a]
+		if ok && !hasAttribute(offs, Attribute(off)) {
+			offs = append(offs, off)
+		}
+	}
+	sort.Strings(offs)
+	return offs
+}
+
+// String gives the name of the attribute, such as "bold".
+func (a Attribute) String() string {
+	if name, ok := attributeNames[a]; ok {
+		return name
+	}
+	return "attribute " + string(a)
+}
+
+func (a Attribute) code() string {
+	return sgr(string(a))
+}
+
+// WithAttribute gives you a new Brush that applies the attribute on top of
+// the colors of the original brush.
+func (b Brush) WithAttribute(a Attribute) Brush {
+	code := a.code()
+	return func(text string) string {
+		if !Enabled() {
+			return b(text)
+		}
+		return b(code + text)
+	}
+}
+
+// Bold gives you a new Brush that also makes the text bold, i.e:
+//
+//	fmt.Println(color.Red.Bold().Underline()("Alert!"))
+func (b Brush) Bold() Brush { return b.WithAttribute(Bold) }
+
+// Dim gives you a new Brush that also makes the text faint.
+func (b Brush) Dim() Brush { return b.WithAttribute(Dim) }
+
+// Italic gives you a new Brush that also makes the text italic.
+func (b Brush) Italic() Brush { return b.WithAttribute(Italic) }
+
+// Underline gives you a new Brush that also underlines the text.
+func (b Brush) Underline() Brush { return b.WithAttribute(Underline) }
+
+// Blink gives you a new Brush that also makes the text blink.
+func (b Brush) Blink() Brush { return b.WithAttribute(Blink) }
+
+// Reverse gives you a new Brush that also swaps the foreground and
+// background colors.
+func (b Brush) Reverse() Brush { return b.WithAttribute(Reverse) }
+
+// Strikethrough gives you a new Brush that also crosses out the text.
+func (b Brush) Strikethrough() Brush { return b.WithAttribute(Strikethrough) }
+
+// RapidBlink copies the current style and return a new Style that also
+// makes the text blink rapidly.  See RapidBlink for terminal support.
+func (s Style) RapidBlink() Style { return s.WithAttributes(RapidBlink) }
+
+// Conceal copies the current style and return a new Style that also hides
+// the text.  See Conceal for terminal support.
+func (s Style) Conceal() Style { return s.WithAttributes(Conceal) }
+
+// CurlyUnderline copies the current style and return a new Style that also
+// has a curly underline.  See CurlyUnderline for terminal support.
+func (s Style) CurlyUnderline() Style { return s.WithAttributes(CurlyUnderline) }
+
+// DottedUnderline copies the current style and return a new Style that also
+// has a dotted underline.  See DottedUnderline for terminal support.
+func (s Style) DottedUnderline() Style { return s.WithAttributes(DottedUnderline) }
+
+// DashedUnderline copies the current style and return a new Style that also
+// has a dashed underline.  See DashedUnderline for terminal support.
+func (s Style) DashedUnderline() Style { return s.WithAttributes(DashedUnderline) }
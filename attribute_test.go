@@ -0,0 +1,177 @@
+package color
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBrushAttributes(t *testing.T) {
+	want := "\033[1;31m" + "\033[1m" + "\033[4m" + "x" + "\033[0m"
+	if got := Red.Bold().Underline()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want = "\033[1;31m" + "x" + "\033[0m"
+	if got := Red("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+var brushAttrTT = []struct {
+	name  string
+	brush Brush
+	attr  Attribute
+}{
+	{"bold", Blue.Bold(), Bold},
+	{"dim", Blue.Dim(), Dim},
+	{"italic", Blue.Italic(), Italic},
+	{"underline", Blue.Underline(), Underline},
+	{"blink", Blue.Blink(), Blink},
+	{"reverse", Blue.Reverse(), Reverse},
+	{"strikethrough", Blue.Strikethrough(), Strikethrough},
+}
+
+func TestAllBrushAttributes(t *testing.T) {
+	for _, test := range brushAttrTT {
+		want := "\033[" + string(BluePaint) + "m" + "\033[" + string(test.attr) + "m" + test.name + "\033[0m"
+		if got := test.brush(test.name); got != want {
+			t.Errorf("Want %#v, got %#v", want, got)
+		}
+	}
+}
+
+func TestStyleAttributes(t *testing.T) {
+	style := NewStyle("", RedPaint).WithAttributes(Underline, Bold).WithAttributes(Italic, Bold)
+
+	want := []Attribute{Bold, Italic, Underline}
+	if got := style.Attributes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	if got := NewStyle("", RedPaint).Attributes(); len(got) != 0 {
+		t.Errorf("Want no attributes, got %#v", got)
+	}
+}
+
+func TestWithAttributesCopies(t *testing.T) {
+	plain := NewStyle("", DarkRedPaint)
+	styled := plain.WithAttributes(Underline, Bold)
+
+	want := "\033[0;31;1;4m" + "x" + "\033[0m"
+	if got := styled.Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	want = "\033[0;31m" + "x" + "\033[0m"
+	if got := plain.Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestStyleAccessors(t *testing.T) {
+	style := NewStyle(BluePaint, RedPaint)
+	if got := style.Background(); got != BluePaint {
+		t.Errorf("Want %#v, got %#v", BluePaint, got)
+	}
+	if got := style.Foreground(); got != RedPaint {
+		t.Errorf("Want %#v, got %#v", RedPaint, got)
+	}
+}
+
+var offTT = []struct {
+	name  string
+	style Style
+	want  string
+}{
+	{"color only", NewStyle("", DarkRedPaint), "\033[39m"},
+	{"bright color", NewStyle("", RedPaint), "\033[22;39m"},
+	{"background", NewStyle(BluePaint, DarkRedPaint), "\033[39;49m"},
+	{"attributes only", Style{}.WithAttributes(Underline, Bold, Dim), "\033[22;24m"},
+	{"everything", NewStyle(BluePaint, DarkRedPaint).WithAttributes(Italic), "\033[23;39;49m"},
+	{"nothing", Style{}, ""},
+}
+
+func TestStyleOff(t *testing.T) {
+	for _, test := range offTT {
+		got := test.style.Off()
+		if got != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, got)
+		}
+		if got == ResetCode() {
+			t.Errorf("%s: Want something else than a full reset", test.name)
+		}
+	}
+}
+
+var underlineTT = []struct {
+	name  string
+	style Style
+	want  string
+}{
+	{"curly", NewStyle("", DarkRedPaint).CurlyUnderline(), "\033[0;31;4:3m"},
+	{"dotted", Style{}.DottedUnderline(), "\033[4:4m"},
+	{"dashed", Style{}.DashedUnderline(), "\033[4:5m"},
+}
+
+func TestStyledUnderlines(t *testing.T) {
+	for _, test := range underlineTT {
+		want := test.want + "text" + "\033[0m"
+		if got := test.style.Brush()("text"); got != want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, want, got)
+		}
+
+		spans := Parse(test.style.Brush()("text"))
+		if len(spans) != 1 || !reflect.DeepEqual(spans[0].Style.Attributes(), test.style.Attributes()) {
+			t.Errorf("%s: Want the underline parsed back, got %#v", test.name, spans)
+		}
+
+		if got := test.style.Off(); !strings.Contains(got, "24") {
+			t.Errorf("%s: Want off code 24, got %#v", test.name, got)
+		}
+	}
+}
+
+func TestRapidBlink(t *testing.T) {
+	// few terminals make rapid blink differ from blink, see RapidBlink
+	style := NewStyle("", RedPaint).RapidBlink()
+
+	want := "\033[1;31;6m" + "alarm" + "\033[0m"
+	if got := style.Brush()("alarm"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want = "\033[22;25;39m"
+	if got := style.WithAttributes(Blink).Off(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	spans := Parse(style.Brush()("alarm") + "\033[6m\033[25m" + "calm")
+	if len(spans) != 2 || !hasAttribute(splitAttributes(spans[0].Style.attrs), RapidBlink) || spans[1].Style != (Style{}) {
+		t.Errorf("Want rapid blink parsed and turned off by 25, got %#v", spans)
+	}
+}
+
+func TestConceal(t *testing.T) {
+	style := NewStyle("", BlackPaint).Conceal()
+
+	want := "\033[0;30;8m" + "secret" + "\033[0m"
+	if got := style.Brush()("secret"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := "\033[28;39m", style.Off(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	spans := Parse("\033[8msecret\033[28mshown")
+	wantSpans := []Span{{Style{}.WithAttributes(Conceal), "secret"}, {Style{}, "shown"}}
+	if !reflect.DeepEqual(spans, wantSpans) {
+		t.Errorf("Want %#v, got %#v", wantSpans, spans)
+	}
+	if n := VisibleLen(style.Brush()("secret")); n != len("secret") {
+		t.Errorf("Want concealed text counted as visible, got %d", n)
+	}
+	// concealed text is still text once its escapes are stripped
+	if want, got := "secret shown", Strip(style.Brush()("secret")+" shown"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
@@ -0,0 +1,182 @@
+package color
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Bar gives you a bar of width cells going smoothly from the from paint to
+// the to paint, painted as the background of blank cells.  A bar of width 1
+// is painted with from only.
+func Bar(from, to Paint, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if !Enabled() {
+		return strings.Repeat(" ", width)
+	}
+
+	var buf bytes.Buffer
+	for i := 0; i < width; i++ {
+		t := 0.0
+		if width > 1 {
+			t = float64(i) / float64(width-1)
+		}
+		code, _ := Blend(from, to, t).BackgroundCode()
+		buf.WriteString(sgr(code) + " ")
+	}
+	buf.WriteString(ResetCode())
+	return buf.String()
+}
+
+// Progress gives you a paint for a health bar like progress indicator,
+// going from red at 0 to yellow at 0.5 and green at 1.  fraction is clamped
+// between 0 and 1.
+func Progress(fraction float64) Paint {
+	return HSV(clamp01(fraction)*120, 1, 1)
+}
+
+// heatmapStops are the colors of Heatmap, from cold to hot.
+var heatmapStops = []Paint{
+	PaintRGB(0, 0, 255),
+	PaintRGB(0, 255, 0),
+	PaintRGB(255, 255, 0),
+	PaintRGB(255, 0, 0),
+}
+
+// Heatmap gives you a paint for value on a heatmap going from blue at min
+// to green, yellow and red at max, i.e. for latencies:
+//
+//	p := color.Heatmap(ms, 0, 500)
+//
+// value is clamped between min and max, and is at min when they're equal
+// or when value is NaN or infinite.
+func Heatmap(value, min, max float64) Paint {
+	t := 0.0
+	if ratio := (value - min) / (max - min); max > min && !math.IsNaN(ratio) && !math.IsInf(ratio, 0) {
+		t = clamp01(ratio)
+	}
+
+	pos := t * float64(len(heatmapStops)-1)
+	k := int(pos)
+	if k >= len(heatmapStops)-1 {
+		k = len(heatmapStops) - 2
+	}
+	return Blend(heatmapStops[k], heatmapStops[k+1], pos-float64(k))
+}
+
+// PercentBar gives you a progress bar of width cells, the first fraction of
+// them drawn as `█` with the filled style and the rest drawn as `░` with the
+// empty style, i.e:
+//
+//	fmt.Println(color.PercentBar(0.42, 20, color.NewStyle("", color.GreenPaint), color.NewStyle("", color.DarkGrayPaint)))
+//
+// fraction is clamped between 0 and 1, partial cells are rounded to the
+// nearest one, and a NaN fraction draws an empty bar.
+func PercentBar(fraction float64, width int, filled, empty Style) string {
+	if width <= 0 {
+		return ""
+	}
+	n := 0
+	if !math.IsNaN(fraction) {
+		n = int(clamp01(fraction)*float64(width) + 0.5)
+	}
+	return filled.Repeat('█', n) + empty.Repeat('░', width-n)
+}
+
+// partialBlocks are the blocks drawing from 1/8 to 7/8 of a cell.
+var partialBlocks = []rune("▏▎▍▌▋▊▉")
+
+// HistogramBar gives you a row of a histogram: a bar of `█` blocks
+// value/max of width cells long, its cells going along the heatmap of
+// Heatmap, so longer bars end hotter.  The last cell is drawn with a
+// partial block, to the nearest eighth, and the bar is padded with spaces
+// to width cells, so that labels following it line up, i.e:
+//
+//	fmt.Println(color.HistogramBar(count, maxCount, 40), count)
+//
+// value is clamped between 0 and max, and a NaN or infinite value/max draws
+// an empty bar.
+func HistogramBar(value, max float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	eighths := 0
+	if ratio := value / max; max > 0 && !math.IsNaN(ratio) && !math.IsInf(ratio, 0) {
+		eighths = int(clamp01(ratio)*float64(width)*8 + 0.5)
+	}
+	cells := []rune(strings.Repeat("█", eighths/8))
+	if rem := eighths % 8; rem > 0 {
+		cells = append(cells, partialBlocks[rem-1])
+	}
+	padding := strings.Repeat(" ", width-len(cells))
+	if len(cells) == 0 || !Enabled() {
+		return string(cells) + padding
+	}
+
+	var buf bytes.Buffer
+	last := ""
+	for i, r := range cells {
+		if code := NewStyle("", Heatmap(float64(i), 0, float64(width-1))).code; code != last {
+			buf.WriteString(code)
+			last = code
+		}
+		buf.WriteRune(r)
+	}
+	buf.WriteString(ResetCode())
+	return buf.String() + padding
+}
+
+// Swatch gives you a sample of the paint, two cells painted with it as
+// their background.
+func Swatch(p Paint) string {
+	return Background(p).colorize("  ")
+}
+
+// Legend gives you a legend for the paints of a chart, one line per label
+// in sorted order, with the labels padded to the longest one and followed by
+// the swatch of their paint, i.e:
+//
+//	fmt.Print(color.Legend(map[string]color.Paint{"reads": color.BluePaint, "writes": color.RedPaint}))
+func Legend(entries map[string]Paint) string {
+	labels := make([]string, 0, len(entries))
+	width := 0
+	for label := range entries {
+		labels = append(labels, label)
+		if n := VisibleLen(label); n > width {
+			width = n
+		}
+	}
+	sort.Strings(labels)
+
+	var buf bytes.Buffer
+	for _, label := range labels {
+		buf.WriteString(label + strings.Repeat(" ", width-VisibleLen(label)+1))
+		buf.WriteString(Swatch(entries[label]) + "\n")
+	}
+	return buf.String()
+}
+
+// SpinnerFrame gives the i-th of the frames of a spinner painted with the
+// style, going back to the first frame after the last one, for animation
+// loops driven by the caller, i.e:
+//
+//	frames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+//	for i := 0; !done(); i++ {
+//		fmt.Printf("\r%s working", color.SpinnerFrame(frames, i, style))
+//		time.Sleep(80 * time.Millisecond)
+//	}
+//
+// It is empty without frames.
+func SpinnerFrame(frames []string, i int, style Style) string {
+	if len(frames) == 0 {
+		return ""
+	}
+	i %= len(frames)
+	if i < 0 {
+		i += len(frames)
+	}
+	return style.colorize(frames[i])
+}
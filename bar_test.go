@@ -0,0 +1,209 @@
+package color
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBar(t *testing.T) {
+	bar := Bar(BlackPaint, WhitePaint, 10)
+
+	first := "\033[48;2;0;0;0m "
+	if !strings.HasPrefix(bar, first) {
+		t.Errorf("Want bar to start with %#v, got %#v", first, bar)
+	}
+
+	last := "\033[48;2;255;255;255m " + "\033[0m"
+	if !strings.HasSuffix(bar, last) {
+		t.Errorf("Want bar to end with %#v, got %#v", last, bar)
+	}
+
+	if n := strings.Count(bar, " "); n != 10 {
+		t.Errorf("Want 10 cells, got %d", n)
+	}
+}
+
+func TestBarEdgeCases(t *testing.T) {
+	if got := Bar(BlackPaint, WhitePaint, 0); got != "" {
+		t.Errorf("Want %#v, got %#v", "", got)
+	}
+
+	want := "\033[48;2;255;0;0m " + "\033[0m"
+	if got := Bar(RedPaint, BluePaint, 1); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+var progressTT = []struct {
+	fraction float64
+	want     Paint
+}{
+	{0, PaintRGB(255, 0, 0)},
+	{0.5, PaintRGB(255, 255, 0)},
+	{1, PaintRGB(0, 255, 0)},
+	{-1, PaintRGB(255, 0, 0)},
+	{2, PaintRGB(0, 255, 0)},
+}
+
+func TestProgress(t *testing.T) {
+	for _, test := range progressTT {
+		if got := Progress(test.fraction); got != test.want {
+			t.Errorf("Progress(%v): Want %#v, got %#v", test.fraction, test.want, got)
+		}
+	}
+}
+
+var heatmapTT = []struct {
+	value float64
+	want  Paint
+}{
+	{0, PaintRGB(0, 0, 255)},
+	{100, PaintRGB(0, 255, 0)},
+	{150, PaintRGB(128, 255, 0)},
+	{200, PaintRGB(255, 255, 0)},
+	{300, PaintRGB(255, 0, 0)},
+	{-50, PaintRGB(0, 0, 255)},
+	{1000, PaintRGB(255, 0, 0)},
+}
+
+func TestHeatmap(t *testing.T) {
+	for _, test := range heatmapTT {
+		if got := Heatmap(test.value, 0, 300); got != test.want {
+			t.Errorf("Heatmap(%v): Want %#v, got %#v", test.value, test.want, got)
+		}
+	}
+
+	if want, got := PaintRGB(0, 0, 255), Heatmap(5, 5, 5); got != want {
+		t.Errorf("min == max: Want %#v, got %#v", want, got)
+	}
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if want, got := PaintRGB(0, 0, 255), Heatmap(value, 0, 1); got != want {
+			t.Errorf("Heatmap(%v): Want %#v, got %#v", value, want, got)
+		}
+	}
+}
+
+var percentBarTT = []struct {
+	fraction float64
+	width    int
+	want     string
+}{
+	{0, 10, "[]" + "{░░░░░░░░░░}"},
+	{0.5, 10, "[█████]" + "{░░░░░}"},
+	{1, 10, "[██████████]{}"},
+	{0.33, 4, "[█]" + "{░░░}"},
+	{0.4, 4, "[██]" + "{░░}"},
+	{2, 3, "[███]{}"},
+	{-1, 3, "[]{░░░}"},
+	{0.5, 0, "[]{}"},
+	{math.NaN(), 4, "[]{░░░░}"},
+}
+
+func TestPercentBar(t *testing.T) {
+	filled, empty := NewStyle("", GreenPaint), NewStyle("", DarkGrayPaint)
+	for _, test := range percentBarTT {
+		// [] and {} stand for the code and reset of each style in the table
+		want := strings.NewReplacer(
+			"[]", "", "{}", "",
+			"[", filled.code, "]", ResetCode(),
+			"{", empty.code, "}", ResetCode(),
+		).Replace(test.want)
+
+		if got := PercentBar(test.fraction, test.width, filled, empty); got != want {
+			t.Errorf("PercentBar(%v, %d): Want %#v, got %#v", test.fraction, test.width, want, got)
+		}
+	}
+}
+
+func TestSwatch(t *testing.T) {
+	want := "\033[48;2;1;2;3m" + "  " + "\033[0m"
+	if got := Swatch(PaintRGB(1, 2, 3)); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestLegend(t *testing.T) {
+	got := Legend(map[string]Paint{
+		"writes":  RedPaint,
+		"reads":   BluePaint,
+		"deletes": Index(200),
+	})
+
+	want := "deletes " + "\033[48;5;200m  \033[0m" + "\n" +
+		"reads   " + "\033[44m  \033[0m" + "\n" +
+		"writes  " + "\033[41m  \033[0m" + "\n"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestSpinnerFrame(t *testing.T) {
+	frames := []string{"|", "/", "-", `\`}
+	style := NewStyle("", CyanPaint)
+
+	for _, test := range []struct {
+		i    int
+		want string
+	}{
+		{0, "|"},
+		{2, "-"},
+		{5, "/"},
+		{-1, `\`},
+	} {
+		if want, got := style.Brush()(test.want), SpinnerFrame(frames, test.i, style); got != want {
+			t.Errorf("frame %d: Want %#v, got %#v", test.i, want, got)
+		}
+	}
+
+	if got := SpinnerFrame(nil, 3, style); got != "" {
+		t.Errorf("Want %#v, got %#v", "", got)
+	}
+}
+
+func TestHistogramBar(t *testing.T) {
+	cell := func(i, width int) string {
+		return NewStyle("", Heatmap(float64(i), 0, float64(width-1))).code
+	}
+
+	if want, got := "    ", HistogramBar(0, 10, 4); got != want {
+		t.Errorf("value 0: Want %#v, got %#v", want, got)
+	}
+
+	want := cell(0, 4) + "█" + cell(1, 4) + "█" + cell(2, 4) + "█" + cell(3, 4) + "█" + ResetCode()
+	if got := HistogramBar(10, 10, 4); got != want {
+		t.Errorf("value == max: Want %#v, got %#v", want, got)
+	}
+	if got := HistogramBar(25, 10, 4); got != want {
+		t.Errorf("value > max: Want %#v, got %#v", want, got)
+	}
+
+	// 2.5 cells: two full blocks and a half one
+	want = cell(0, 4) + "█" + cell(1, 4) + "█" + cell(2, 4) + "▌" + ResetCode() + " "
+	if got := HistogramBar(5, 8, 4); got != want {
+		t.Errorf("partial: Want %#v, got %#v", want, got)
+	}
+
+	if want, got := "  ", HistogramBar(1, 0, 2); got != want {
+		t.Errorf("max 0: Want %#v, got %#v", want, got)
+	}
+	for _, test := range []struct {
+		name       string
+		value, max float64
+	}{
+		{"NaN value", math.NaN(), 10},
+		{"NaN max", 5, math.NaN()},
+		{"both infinite", math.Inf(1), math.Inf(1)},
+		{"infinite value", math.Inf(1), 10},
+		{"infinite max", 5, math.Inf(1)},
+	} {
+		if want, got := "    ", HistogramBar(test.value, test.max, 4); got != want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, want, got)
+		}
+	}
+	WithDisabled(func() {
+		if want, got := "██▌ ", HistogramBar(5, 8, 4); got != want {
+			t.Errorf("disabled: Want %#v, got %#v", want, got)
+		}
+	})
+}
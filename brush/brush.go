@@ -20,7 +20,7 @@ import (
 type Black string
 
 func (b Black) String() string {
-	return color.NewBrush(color.WhitePaint, color.BlackPaint)(string(b))
+	return color.Black(string(b))
 }
 
 // White gives white text on a dark gray background. Use it like this:
@@ -28,7 +28,7 @@ func (b Black) String() string {
 type White string
 
 func (w White) String() string {
-	return color.NewBrush(color.DarkGrayPaint, color.WhitePaint)(string(w))
+	return color.White(string(w))
 }
 
 // LightGray gives light gray text on a black background. Use it like this:
@@ -36,7 +36,7 @@ func (w White) String() string {
 type LightGray string
 
 func (l LightGray) String() string {
-	return color.NewBrush("", color.LightGrayPaint)(string(l))
+	return color.LightGray(string(l))
 }
 
 // Blue gives blue text on a black background. Use it like this:
@@ -44,7 +44,7 @@ func (l LightGray) String() string {
 type Blue string
 
 func (b Blue) String() string {
-	return color.NewBrush("", color.BluePaint)(string(b))
+	return color.Blue(string(b))
 }
 
 // Cyan gives cyan text on a black background. Use it like this:
@@ -52,7 +52,7 @@ func (b Blue) String() string {
 type Cyan string
 
 func (c Cyan) String() string {
-	return color.NewBrush("", color.CyanPaint)(string(c))
+	return color.Cyan(string(c))
 }
 
 // Green gives green text on a black background. Use it like this:
@@ -60,7 +60,7 @@ func (c Cyan) String() string {
 type Green string
 
 func (g Green) String() string {
-	return color.NewBrush("", color.GreenPaint)(string(g))
+	return color.Green(string(g))
 }
 
 // Purple gives purple text on a black background. Use it like this:
@@ -68,7 +68,7 @@ func (g Green) String() string {
 type Purple string
 
 func (p Purple) String() string {
-	return color.NewBrush("", color.PurplePaint)(string(p))
+	return color.Purple(string(p))
 }
 
 // Red gives red text on a black background. Use it like this:
@@ -76,7 +76,7 @@ func (p Purple) String() string {
 type Red string
 
 func (r Red) String() string {
-	return color.NewBrush("", color.RedPaint)(string(r))
+	return color.Red(string(r))
 }
 
 // Yellow gives yellow text on a black background. Use it like this:
@@ -84,7 +84,7 @@ func (r Red) String() string {
 type Yellow string
 
 func (y Yellow) String() string {
-	return color.NewBrush("", color.YellowPaint)(string(y))
+	return color.Yellow(string(y))
 }
 
 // DarkBlue gives dark blue text on a black background. Use it like this:
@@ -92,7 +92,7 @@ func (y Yellow) String() string {
 type DarkBlue string
 
 func (d DarkBlue) String() string {
-	return color.NewBrush("", color.DarkBluePaint)(string(d))
+	return color.DarkBlue(string(d))
 }
 
 // DarkCyan gives dark cyan text on a black background. Use it like this:
@@ -100,7 +100,7 @@ func (d DarkBlue) String() string {
 type DarkCyan string
 
 func (d DarkCyan) String() string {
-	return color.NewBrush("", color.DarkCyanPaint)(string(d))
+	return color.DarkCyan(string(d))
 }
 
 // DarkGray gives dark gray text on a black background. Use it like this:
@@ -108,7 +108,7 @@ func (d DarkCyan) String() string {
 type DarkGray string
 
 func (d DarkGray) String() string {
-	return color.NewBrush("", color.DarkGrayPaint)(string(d))
+	return color.DarkGray(string(d))
 }
 
 // DarkGreen gives dark green text on a black background. Use it like this:
@@ -116,7 +116,7 @@ func (d DarkGray) String() string {
 type DarkGreen string
 
 func (d DarkGreen) String() string {
-	return color.NewBrush("", color.DarkGreenPaint)(string(d))
+	return color.DarkGreen(string(d))
 }
 
 // DarkPurple gives dark purple text on a black background. Use it like this:
@@ -124,7 +124,7 @@ func (d DarkGreen) String() string {
 type DarkPurple string
 
 func (d DarkPurple) String() string {
-	return color.NewBrush("", color.DarkPurplePaint)(string(d))
+	return color.DarkPurple(string(d))
 }
 
 // DarkRed gives dark red text on a black background. Use it like this:
@@ -132,7 +132,7 @@ func (d DarkPurple) String() string {
 type DarkRed string
 
 func (d DarkRed) String() string {
-	return color.NewBrush("", color.DarkRedPaint)(string(d))
+	return color.DarkRed(string(d))
 }
 
 // DarkYellow gives brown text on a black background. Use it like this:
@@ -140,5 +140,5 @@ func (d DarkRed) String() string {
 type DarkYellow string
 
 func (d DarkYellow) String() string {
-	return color.NewBrush("", color.DarkYellowPaint)(string(d))
+	return color.DarkYellow(string(d))
 }
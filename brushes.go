@@ -0,0 +1,80 @@
+package color
+
+// Brushes for the standard paints, the same as the ones in sub-package
+// brush.  They can be invoked directly or chained with attributes:
+//
+//	fmt.Printf("This is %s\n", color.Red("red"))
+//	fmt.Printf("This is %s\n", color.Red.Bold()("bold red"))
+var (
+	Black      = BlackOn(WhitePaint)
+	White      = WhiteOn(DarkGrayPaint)
+	LightGray  = lazyBrush("", LightGrayPaint)
+	Blue       = lazyBrush("", BluePaint)
+	Cyan       = lazyBrush("", CyanPaint)
+	Green      = lazyBrush("", GreenPaint)
+	Purple     = lazyBrush("", PurplePaint)
+	Red        = lazyBrush("", RedPaint)
+	Yellow     = lazyBrush("", YellowPaint)
+	DarkBlue   = lazyBrush("", DarkBluePaint)
+	DarkCyan   = lazyBrush("", DarkCyanPaint)
+	DarkGray   = lazyBrush("", DarkGrayPaint)
+	DarkGreen  = lazyBrush("", DarkGreenPaint)
+	DarkPurple = lazyBrush("", DarkPurplePaint)
+	DarkRed    = lazyBrush("", DarkRedPaint)
+	DarkYellow = lazyBrush("", DarkYellowPaint)
+)
+
+// Brushes painting only the background with the standard paints, leaving
+// the foreground to the terminal's default:
+//
+//	fmt.Println(color.OnRed(" FAIL "), name)
+//
+// The bright paints give the bright `10x` backgrounds, so OnRed and
+// OnDarkRed differ like Red and DarkRed do.
+var (
+	OnBlack      = lazyBrush(BlackPaint, "")
+	OnWhite      = lazyBrush(brightPaint(WhitePaint), "")
+	OnLightGray  = lazyBrush(LightGrayPaint, "")
+	OnBlue       = lazyBrush(brightPaint(BluePaint), "")
+	OnCyan       = lazyBrush(brightPaint(CyanPaint), "")
+	OnGreen      = lazyBrush(brightPaint(GreenPaint), "")
+	OnPurple     = lazyBrush(brightPaint(PurplePaint), "")
+	OnRed        = lazyBrush(brightPaint(RedPaint), "")
+	OnYellow     = lazyBrush(brightPaint(YellowPaint), "")
+	OnDarkBlue   = lazyBrush(DarkBluePaint, "")
+	OnDarkCyan   = lazyBrush(DarkCyanPaint, "")
+	OnDarkGray   = lazyBrush(brightPaint(DarkGrayPaint), "")
+	OnDarkGreen  = lazyBrush(DarkGreenPaint, "")
+	OnDarkPurple = lazyBrush(DarkPurplePaint, "")
+	OnDarkRed    = lazyBrush(DarkRedPaint, "")
+	OnDarkYellow = lazyBrush(DarkYellowPaint, "")
+)
+
+// Brushes for test results, as in the output of test runners:
+//
+//	fmt.Println(color.Pass("PASS"), name)
+var (
+	Pass = lazyBrush("", GreenPaint)
+	Fail = lazyBrush("", RedPaint)
+	Skip = lazyBrush("", YellowPaint)
+)
+
+// BlackOn gives you a Brush painting black text on the given background.
+// Black uses a white background.
+func BlackOn(background Paint) Brush {
+	return lazyBrush(background, BlackPaint)
+}
+
+// WhiteOn gives you a Brush painting white text on the given background.
+// White uses a dark gray background.
+func WhiteOn(background Paint) Brush {
+	return lazyBrush(background, WhitePaint)
+}
+
+// lazyBrush gives a Brush computing its style on each call, so that changes
+// to the package settings apply to the package level brushes.
+func lazyBrush(background, foreground Paint) Brush {
+	return func(text string) string {
+		return NewBrush(background, foreground)(text)
+	}
+}
@@ -0,0 +1,84 @@
+package color
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMonochromeOn(t *testing.T) {
+	want := "\033[" + string(BlackPaint) + "m" + "\033[44m" + "black" + "\033[0m"
+	if got := BlackOn(BluePaint)("black"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want = "\033[" + string(WhitePaint) + "m" + "\033[41m" + "white" + "\033[0m"
+	if got := WhiteOn(DarkRedPaint)("white"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// the black text is shown on the background
+	wantSpans := []Span{{NewStyle("34", "30"), "black"}}
+	if got := Parse(BlackOn(DarkBluePaint)("black")); !reflect.DeepEqual(got, wantSpans) {
+		t.Errorf("Want %#v, got %#v", wantSpans, got)
+	}
+	if want, got := "\033[0;30m"+"\033[47m"+"x"+"\033[0m", Black("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestMonochromeDefaults(t *testing.T) {
+	if want, got := BlackOn(WhitePaint)("x"), Black("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := WhiteOn(DarkGrayPaint)("x"), White("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestTestResultBrushes(t *testing.T) {
+	for _, test := range []struct {
+		brush Brush
+		paint Paint
+	}{
+		{Pass, GreenPaint},
+		{Fail, RedPaint},
+		{Skip, YellowPaint},
+	} {
+		want := "\033[" + string(test.paint) + "m" + "PASS" + "\033[0m"
+		if got := test.brush("PASS"); got != want {
+			t.Errorf("Want %#v, got %#v", want, got)
+		}
+	}
+
+	Disable()
+	defer Enable()
+	if got := Fail("FAIL"); got != "FAIL" {
+		t.Errorf("Want %#v, got %#v", "FAIL", got)
+	}
+}
+
+func TestBackgroundBrushes(t *testing.T) {
+	for _, test := range []struct {
+		brush Brush
+		code  string
+	}{
+		{OnBlack, "40"},
+		{OnDarkRed, "41"},
+		{OnDarkYellow, "43"},
+		{OnLightGray, "47"},
+		{OnDarkGray, "100"},
+		{OnRed, "101"},
+		{OnBlue, "104"},
+		{OnWhite, "107"},
+	} {
+		want := "\033[" + test.code + "m" + "x" + "\033[0m"
+		if got := test.brush("x"); got != want {
+			t.Errorf("Want %#v, got %#v", want, got)
+		}
+	}
+
+	want := "\033[45m" + "x" + "\033[0m"
+	if got := Background(DarkPurplePaint).Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
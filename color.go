@@ -1,11 +1,32 @@
 package color
 
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
 const (
-	pre   = "\033["
-	post  = ``
-	reset = "\033[0m"
+	pre  = "\033["
+	post = ``
 )
 
+// ResetCode gives the sequence resetting the terminal to its default style,
+// the one every Brush emits after its text.  It lets you end a style you
+// started by hand.  It follows the ResetMode, and is empty when colors are
+// disabled.  With ResetColors it only restores the colors, the brushes
+// also turn off the attributes of their style.
+func ResetCode() string {
+	if !Enabled() {
+		return ""
+	}
+	return resetSeq()
+}
+
 // Paint is a color to paint, either as a foreground or background paint
 type Paint string
 
@@ -30,6 +51,11 @@ const (
 	WhitePaint    Paint = `1;37`
 )
 
+// NoPaint is the absence of a paint, leaving the default color of the
+// terminal.  Give it to NewStyle, WithBackground or WithForeground for a
+// transparent background or a default foreground.
+const NoPaint Paint = ``
+
 // Brush is a function that let's you colorize strings directly.
 type Brush func(string) string
 
@@ -41,21 +67,87 @@ func NewBrush(background, foreground Paint) Brush {
 
 // Style will give you colorized strings.  Styles are immutable.
 type Style struct {
-	bg   Paint
-	fg   Paint
-	code string
+	bg    Paint
+	fg    Paint
+	attrs string
+	raw   string // parameters given to WithRaw
+	code  string
+	csi   string // introducer code was computed with
 }
 
 // NewStyle gives you a style ready to produce strings with the given
 // background and foreground colors
 func NewStyle(background, foreground Paint) Style {
-	bg := background
-	fg := foreground
-	return Style{
-		bg,
-		fg,
-		computeColorCode(bg, fg),
+	s := Style{bg: background, fg: foreground}
+	s.code, s.csi = computeColorCode(s.bg, s.fg, "")
+	return s
+}
+
+// Background gives you a style painting only the background, leaving the
+// foreground to the terminal's default, i.e:
+//
+//    highlight := Background(DarkYellowPaint).Brush()
+//
+// Background gives a Style rather than a Brush, since Sequence and the
+// With methods take its styles: call Brush on it, or use the On brushes
+// such as OnRed for the palette.
+func Background(p Paint) Style {
+	return NewStyle(p, "")
+}
+
+// Background gives the background paint of the style.
+func (s Style) Background() Paint {
+	return s.bg
+}
+
+// Foreground gives the foreground paint of the style.
+func (s Style) Foreground() Paint {
+	return s.fg
+}
+
+// String describes the style, the same way for the same style, i.e. for
+// snapshot tests: `Style(fg=1;31 bg=nil attrs=bold,underline)`.  Paints are
+// given as written and attributes by name, and raw parameters follow them.
+func (s Style) String() string {
+	paint := func(p Paint) string {
+		if p == "" {
+			return "nil"
+		}
+		return string(p)
+	}
+	attrs := "none"
+	if all := s.Attributes(); len(all) > 0 {
+		names := make([]string, len(all))
+		for i, a := range all {
+			names[i] = a.String()
+		}
+		attrs = strings.Join(names, ",")
+	}
+	desc := "Style(fg=" + paint(s.fg) + " bg=" + paint(s.bg) + " attrs=" + attrs
+	if s.raw != "" {
+		desc += " raw=" + s.raw
+	}
+	return desc + ")"
+}
+
+// Equal tells if two styles have the same paints, the same attributes and
+// the same raw parameters, however they were built.  Paints naming the same
+// color, as told by Normalize, are the same, but the bold of the `1;3x`
+// paints counts as an attribute: RedPaint is only equal to `91` with Bold.
+func (s Style) Equal(other Style) bool {
+	return s.fg.Normalize() == other.fg.Normalize() &&
+		s.bg.Normalize() == other.bg.Normalize() &&
+		s.shownAttrs() == other.shownAttrs() &&
+		s.raw == other.raw
+}
+
+// shownAttrs gives the attributes of the style with the bold implied by
+// a `1;3x` foreground.
+func (s Style) shownAttrs() string {
+	if strings.HasPrefix(string(s.fg), "1;") {
+		return addAttributes(s.attrs, Bold)
 	}
+	return s.attrs
 }
 
 // Brush is a function that can be used to color things directly, i.e:
@@ -63,40 +155,519 @@ func NewStyle(background, foreground Paint) Style {
 //    red := NewStyle(BlackPaint, RedPaint).Brush()
 //    fmt.Printf("This is %s\n", red("red"))
 func (s Style) Brush() Brush {
+	return s.colorize
+}
+
+// Formatter gives you a function formatting its arguments with the format,
+// like fmt.Sprintf, and painting the result with the style, i.e:
+//
+//    warnf := NewStyle("", YellowPaint).Formatter("WARN: %s")
+//    log.Println(warnf("disk almost full"))
+func (s Style) Formatter(format string) func(args ...interface{}) string {
+	return func(args ...interface{}) string {
+		return s.colorize(fmt.Sprintf(format, args...))
+	}
+}
+
+// Sprintf formats like fmt.Sprintf and paints the result with the style.
+func (s Style) Sprintf(format string, args ...interface{}) string {
+	return s.colorize(fmt.Sprintf(format, args...))
+}
+
+// Sprintf formats like fmt.Sprintf and paints the result with the brush,
+// i.e:
+//
+//    log.Println(Yellow.Sprintf("retry %d of %d", i, n))
+func (b Brush) Sprintf(format string, args ...interface{}) string {
+	return b(fmt.Sprintf(format, args...))
+}
+
+// Fprintf formats like fmt.Fprintf and writes the result painted with the
+// brush to w.  It gives the number of bytes written, codes included, and
+// any write error.
+func (b Brush) Fprintf(w io.Writer, format string, args ...interface{}) (n int, err error) {
+	return io.WriteString(w, b(fmt.Sprintf(format, args...)))
+}
+
+// colorize paints text with the style, unless colors are disabled.  Styles
+// without paints nor attributes leave it plain.
+func (s Style) colorize(text string) string {
+	if !Enabled() || s.code == "" {
+		return text
+	}
+	return s.code + text + s.reset()
+}
+
+// BrushNonEmpty is like Brush, but the returned Brush leaves empty strings
+// untouched instead of wrapping them in a color code and a reset, i.e:
+//
+//    opt := NewStyle(BlackPaint, RedPaint).BrushNonEmpty()
+//    fmt.Printf("[%s]\n", opt("")) // prints "[]"
+func (s Style) BrushNonEmpty() Brush {
+	return func(text string) string {
+		if text == "" {
+			return ""
+		}
+		return s.colorize(text)
+	}
+}
+
+// Trimmed is like Brush, but the returned Brush leaves the leading and
+// trailing whitespace of the text plain, so that backgrounds don't spill
+// over the padding of a cell, i.e:
+//
+//    cell := NewStyle(DarkBluePaint, WhitePaint).Trimmed()
+//    fmt.Printf("|%s|\n", cell("  42  ")) // only "42" is painted
+//
+// Text made only of whitespace is left plain.
+func (s Style) Trimmed() Brush {
 	return func(text string) string {
-		return s.code + text + reset
+		content := strings.TrimLeftFunc(text, unicode.IsSpace)
+		lead := text[:len(text)-len(content)]
+		content = strings.TrimRightFunc(content, unicode.IsSpace)
+		if content == "" {
+			return text
+		}
+		trail := text[len(lead)+len(content):]
+		return lead + s.colorize(content) + trail
+	}
+}
+
+// PerLine is like Brush, but the returned Brush paints each line of the
+// text separately, with a code and a reset around each of them, so that
+// backgrounds don't run past the end of lines in the terminals where they
+// otherwise would.  The newlines, and the empty lines, are left plain.
+func (s Style) PerLine() Brush {
+	return func(text string) string {
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = s.colorize(line)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// Isolated is like Brush, but the returned Brush resets the terminal before
+// applying the style.  The colored string is then self-contained and never
+// inherits whatever style was active before it, which is handy for library
+// code that doesn't know where its output ends up.
+func (s Style) Isolated() Brush {
+	return func(text string) string {
+		return ResetCode() + s.colorize(text)
+	}
+}
+
+// Wrap paints text like Brush, but re-applies the style after each reset
+// within the text, so that the style resumes after the nested colored
+// parts, i.e:
+//
+//    red := NewStyle("", RedPaint)
+//    fmt.Println(red.Wrap("error: " + Blue("file") + " not found"))
+//
+// prints "not found" in red again, when Brush would leave it plain.
+func (s Style) Wrap(text string) string {
+	if !Enabled() || s.code == "" {
+		return text
+	}
+	var b strings.Builder
+	resume := false
+	eachToken(text, func(tok string, escape bool) {
+		if resume {
+			b.WriteString(s.code)
+			resume = false
+		}
+		b.WriteString(tok)
+		resume = escape && s.endedBy(tok)
+	})
+	return s.colorize(b.String())
+}
+
+// endedBy tells if seq is a reset, the style's own reset, or the reset of
+// a nested brush in the ResetColors mode: the default colors with only the
+// off codes of attributes.  Sequences setting anything, such as the italic
+// of `\033[3;39;49m`, don't end the style.
+func (s Style) endedBy(seq string) bool {
+	if isReset(seq) || seq == s.reset() {
+		return true
 	}
+	params, ok := sgrParams([]byte(seq))
+	if !ok || !strings.HasSuffix(params, "39;49") {
+		return false
+	}
+	for _, param := range splitSGR(params) {
+		switch n := param.n; {
+		case n == 39 || n == 49:
+		case n >= 22 && n <= 29 && n != 26:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// BrushLine paints text and then erases the rest of the line with the
+// style, so that its background reaches the edge of the terminal.  This is
+// the usual way to draw full width status bars, i.e:
+//
+//    fmt.Println(NewStyle(DarkBluePaint, WhitePaint).BrushLine(" 3 files changed"))
+func (s Style) BrushLine(text string) string {
+	if !Enabled() {
+		return text
+	}
+	return s.code + text + s.seq("", 'K') + s.reset()
+}
+
+// FillLine is like BrushLine, but sets the style again right before
+// erasing the rest of the line, so that the background fills the line even
+// if text ends with a reset of its own, such as colored parts.  Status lines
+// redrawn in place keep their full width background this way, i.e:
+//
+//    fmt.Print("\r", bar.FillLine("build "+Green("ok")))
+func (s Style) FillLine(text string) string {
+	if !Enabled() {
+		return text
+	}
+	return s.code + text + s.code + s.seq("", 'K') + s.reset()
+}
+
+// BufferedBrush is like Brush, but the returned function appends the
+// painted text to dst and returns the extended slice, like the append
+// functions of package strconv.  Reusing dst, it paints without allocating,
+// i.e:
+//
+//    paint := NewStyle("", RedPaint).BufferedBrush()
+//    buf = paint(buf[:0], "error")
+//
+// The reset is computed once, when BufferedBrush is called.
+func (s Style) BufferedBrush() func(dst []byte, text string) []byte {
+	code, reset := s.code, s.reset()
+	return func(dst []byte, text string) []byte {
+		if !Enabled() || code == "" {
+			return append(dst, text...)
+		}
+		dst = append(dst, code...)
+		dst = append(dst, text...)
+		return append(dst, reset...)
+	}
+}
+
+// AppendTo writes text painted with the style into b, without building
+// the painted string first, to assemble large documents, i.e:
+//
+//    var b strings.Builder
+//    for _, line := range lines {
+//        style.AppendTo(&b, line)
+//        b.WriteByte('\n')
+//    }
+//
+// Writes to a strings.Builder never fail, so there's no error to return.
+func (s Style) AppendTo(b *strings.Builder, text string) {
+	if !Enabled() || s.code == "" {
+		b.WriteString(text)
+		return
+	}
+	b.WriteString(s.code)
+	b.WriteString(text)
+	b.WriteString(s.reset())
+}
+
+// BrushAll paints the concatenation of parts with the style, with a single
+// code and reset around all of them rather than one around each part.  It
+// gives an empty string for no parts.
+func (s Style) BrushAll(parts ...string) string {
+	if len(parts) == 0 {
+		return ""
+	}
+	return s.colorize(strings.Join(parts, ""))
+}
+
+// Repeat gives you n copies of r painted with the style, with a single code
+// and reset around them, i.e:
+//
+//    fmt.Println(NewStyle("", DarkGrayPaint).Repeat('─', 40))
+func (s Style) Repeat(r rune, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return s.colorize(strings.Repeat(string(r), n))
+}
+
+// First paints the first n runes of text with the style and leaves the rest
+// plain, i.e:
+//
+//    fmt.Println(NewStyle("", CyanPaint).First(2, `C:\Windows`))
+//
+// The whole text is painted if it's shorter than n.
+func (s Style) First(n int, text string) string {
+	i := runeOffset(text, n)
+	if i == 0 {
+		return text
+	}
+	return s.colorize(text[:i]) + text[i:]
+}
+
+// Last paints the last n runes of text with the style and leaves the rest
+// plain.  The whole text is painted if it's shorter than n.
+func (s Style) Last(n int, text string) string {
+	i := runeOffset(text, utf8.RuneCountInString(text)-n)
+	if i == len(text) {
+		return text
+	}
+	return text[:i] + s.colorize(text[i:])
+}
+
+// runeOffset gives the byte offset of the n-th rune of text, clamped to the
+// text.
+func runeOffset(text string, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	for i := range text {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(text)
+}
+
+// Overhead gives the number of bytes the style adds to each string it
+// paints, its code and the reset after it.  It is 0 when colors are
+// disabled, and for styles painting nothing.
+func Overhead(s Style) int {
+	if !Enabled() || s.code == "" {
+		return 0
+	}
+	return len(s.code) + len(s.reset())
 }
 
 // WithBackground copies the current style and return a new Style that
 // has the desired background. The original Style is unchanged and you
-// must capture the return value.
+// must capture the return value.  NoPaint drops the background.
 func (s Style) WithBackground(color Paint) Style {
 	newS := s
 	newS.bg = color
-	newS.code = computeColorCode(newS.bg, newS.fg)
+	newS.code, newS.csi = computeColorCode(newS.bg, newS.fg, newS.params())
 	return newS
 }
 
 // WithForeground copies the current style and return a new Style that
 // has the desired foreground. The original Style is unchanged and you
-// must capture the return value.
+// must capture the return value.  NoPaint drops the foreground.
 func (s Style) WithForeground(color Paint) Style {
 	newS := s
 	newS.fg = color
-	newS.code = computeColorCode(newS.bg, newS.fg)
+	newS.code, newS.csi = computeColorCode(newS.bg, newS.fg, newS.params())
 	return newS
 }
 
-func computeColorCode(bg, fg Paint) string {
-	if bg == "" {
-		return pre + string(fg) + "m" + post
+// WithBrightBackground copies the current style and return a new Style that
+// has the bright variant of the desired color as background, i.e:
+//
+//    NewStyle("", WhitePaint).WithBrightBackground(RedPaint)
+//
+// paints white text on a bright red background (`\033[101m`).  Both the dark
+// and the bright paints of a color give the same bright background.  The
+// 256 colors and truecolor paints have no bright variant and are used as
+// they are.
+func (s Style) WithBrightBackground(color Paint) Style {
+	return s.WithBackground(brightPaint(color))
+}
+
+// brightPaint gives the `9x` form of one of the 16 standard paints, the
+// bright variant of its color.  Other paints are given back unchanged.
+func brightPaint(p Paint) Paint {
+	i, ok := p.index16()
+	if !ok {
+		return p
 	}
+	return Paint("9" + strconv.Itoa(i%8))
+}
+
+// WithRaw copies the current style and return a new Style that also emits
+// the given SGR parameters, such as "53" for overlined text, for the codes
+// this package has no API for.  params must be numbers separated by
+// semicolons, otherwise the style is returned unchanged.  Off doesn't know
+// how to turn raw parameters off.
+func (s Style) WithRaw(params string) Style {
+	if !rawParams.MatchString(params) {
+		return s
+	}
+	newS := s
+	newS.raw = joinParams(s.raw, params)
+	newS.code, newS.csi = computeColorCode(newS.bg, newS.fg, newS.params())
+	return newS
+}
 
-	// The background code is the last color code prefixed by 4
-	bgColor := bg[len(bg)-1]
-	back := pre + "4" + string(bgColor) + "m" + post
+// rawParams matches the parameters accepted by WithRaw.
+var rawParams = regexp.MustCompile(`^[0-9]+(;[0-9]+)*$`)
 
-	front := pre + string(fg) + "m" + post
-	return back + front
+// params gives the SGR parameters following the foreground: the attributes
+// and then the raw parameters.
+func (s Style) params() string {
+	return joinParams(s.attrs, s.raw)
+}
+
+// Sequence gives a single SGR sequence turning on all the parts, for when
+// you manage the resets yourself, i.e:
+//
+//    fmt.Print(Sequence(WhitePaint, Background(DarkRedPaint), Bold), " FAIL ", ResetCode())
+//
+// The parts can be Paints, painting the foreground, Attributes, and Styles,
+// adding their paints and attributes, of which Background gives you
+// backgrounds.  Later paints replace earlier ones, and Bold is left out
+// with the bright paint constants, bold already.  Sequence panics when
+// given anything else, and gives an empty string for no parts or when colors
+// are disabled.
+func Sequence(parts ...interface{}) string {
+	var merged Style
+	for _, part := range parts {
+		switch part := part.(type) {
+		case Paint:
+			merged.fg = part
+		case Attribute:
+			merged.attrs = addAttributes(merged.attrs, part)
+		case Style:
+			if part.bg != "" {
+				merged.bg = part.bg
+			}
+			if part.fg != "" {
+				merged.fg = part.fg
+			}
+			merged.attrs = addAttributes(merged.attrs, part.Attributes()...)
+			merged.raw = joinParams(merged.raw, part.raw)
+		default:
+			panic(fmt.Sprintf("color: Sequence can't take a %T", part))
+		}
+	}
+	if !Enabled() {
+		return ""
+	}
+
+	bg, fg := merged.bg, merged.fg
+	if level := ColorLevel(); level < LevelTrueColor {
+		bg, fg = bg.downsample(level), fg.downsample(level)
+	}
+	// the foreground first, the `0;` of the dark paints resets what's
+	// before it
+	params := string(compactPaint(fg))
+	if back, ok := bg.BackgroundCode(); ok {
+		params = joinParams(params, back)
+	}
+	params = joinParams(params, dropImpliedBold(fg, merged.params()))
+	if params == "" {
+		return ""
+	}
+	return sgr(params)
+}
+
+// MinimalCode gives the shortest sequence with the look of the style on
+// terminals in their default state, such as right after a reset: a single
+// SGR sequence, without the leading `0;` of the dark paints nor the default
+// foreground `39`, and without attributes repeating the bold of the `1;`
+// paints.  Unlike the style's own code, it doesn't undo the colors
+// already active, so only use it where nothing else is.  MinimalCode gives
+// an empty string when colors are disabled.
+func (s Style) MinimalCode() string {
+	if !Enabled() {
+		return ""
+	}
+	bg, fg := s.bg, s.fg
+	if level := ColorLevel(); level < LevelTrueColor {
+		bg, fg = bg.downsample(level), fg.downsample(level)
+	}
+
+	front := strings.TrimPrefix(string(fg), "0;")
+	attrs := s.attrs
+	if strings.HasPrefix(front, "1;") {
+		front = front[2:]
+		attrs = addAttributes(attrs, Bold)
+	}
+	if front == "39" {
+		front = ""
+	}
+	params := front
+	if back, ok := bg.BackgroundCode(); ok {
+		params = joinParams(params, back)
+	}
+	params = joinParams(params, joinParams(attrs, s.raw))
+	if params == "" {
+		return ""
+	}
+	return sgr(params)
+}
+
+// dropImpliedBold removes Bold from the parameters following fg when fg
+// is a `1;3x` paint, bold already.  Bold sorts first among the attributes.
+func dropImpliedBold(fg Paint, params string) string {
+	if !strings.HasPrefix(string(fg), "1;") {
+		return params
+	}
+	if params == string(Bold) {
+		return ""
+	}
+	return strings.TrimPrefix(params, string(Bold)+";")
+}
+
+func joinParams(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + ";" + b
+}
+
+// computeColorCode gives the code of a style at the current color level
+// and the control sequence introducer it starts with.
+func computeColorCode(bg, fg Paint, attrs string) (string, string) {
+	return colorCodeAt(ColorLevel(), bg, fg, attrs)
+}
+
+// colorCodeAt is computeColorCode at the given level, for the styles
+// downsampled to a level of their own.
+func colorCodeAt(level Level, bg, fg Paint, attrs string) (string, string) {
+	prefix := currentCSI()
+	sgr := func(params string) string { return prefix + params + "m" + post }
+	if level < LevelTrueColor {
+		bg, fg = bg.downsample(level), fg.downsample(level)
+	}
+
+	// Attributes and the background come after the foreground, so that
+	// the `0;` prefix of the dark paints doesn't reset them
+	back, ok := bg.BackgroundCode()
+	params := joinParams(string(compactPaint(fg)), dropImpliedBold(fg, attrs))
+
+	switch {
+	case !ok && params == "":
+		return "", prefix
+	case !ok:
+		return sgr(params), prefix
+	case params == "":
+		// an empty SGR is a reset, it would undo the background
+		return sgr(back), prefix
+	}
+	return sgr(params) + sgr(back), prefix
+}
+
+// BackgroundCode gives the SGR parameters painting p as a background, such
+// as `41` for DarkRedPaint or `48;5;200` for a 256 colors paint.  The `0;3x`
+// and `1;3x` paints both give the `4x` background, use the `9x` form for the
+// bright `10x` backgrounds.  ok is false if the paint has no background
+// form, such as the empty paint or the default foreground `39`.
+func (p Paint) BackgroundCode() (code string, ok bool) {
+	s := string(p)
+	switch {
+	case strings.HasPrefix(s, "38;5;"), strings.HasPrefix(s, "38;2;"):
+		return "48;" + s[3:], true
+	case len(s) == 2 && s[0] == '9':
+		if _, ok := p.index16(); ok {
+			return "10" + s[1:], true
+		}
+	default:
+		if _, ok := p.index16(); ok {
+			return "4" + s[len(s)-1:], true
+		}
+	}
+	return "", false
 }
@@ -1,7 +1,10 @@
 package color
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -64,6 +67,75 @@ func TestStylesImmutable(t *testing.T) {
 
 }
 
+func TestNoPaintBackground(t *testing.T) {
+	style := NewStyle(NoPaint, YellowPaint)
+	want := "\033[1;33m" + "text" + "\033[0m"
+	if got := style.Brush()("text"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// setting a background and clearing it again
+	withBg := style.WithBackground(DarkBluePaint)
+	wantBg := "\033[1;33m" + "\033[44m" + "text" + "\033[0m"
+	if got := withBg.Brush()("text"); got != wantBg {
+		t.Errorf("Want %#v, got %#v", wantBg, got)
+	}
+	cleared := withBg.WithBackground(NoPaint)
+	if got := cleared.Brush()("text"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if cleared != style {
+		t.Errorf("Want %#v, got %#v", style, cleared)
+	}
+
+	// a transparent background keeps the attributes and the raw parameters
+	styled := withBg.WithAttributes(Bold).WithRaw("53").WithBackground(NoPaint)
+	want = "\033[1;33;53m" + "text" + "\033[0m"
+	if got := styled.Brush()("text"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestStyleString(t *testing.T) {
+	for _, test := range []struct {
+		style Style
+		want  string
+	}{
+		{NewStyle("", RedPaint), "Style(fg=1;31 bg=nil attrs=none)"},
+		{NewStyle(DarkBluePaint, Index(208)).WithAttributes(Underline, Bold), "Style(fg=38;5;208 bg=0;34 attrs=bold,underline)"},
+		{Style{}.WithAttributes(RapidBlink).WithRaw("53"), "Style(fg=nil bg=nil attrs=rapid blink raw=53)"},
+		{Style{}, "Style(fg=nil bg=nil attrs=none)"},
+	} {
+		if got := test.style.String(); got != test.want {
+			t.Errorf("Want %#v, got %#v", test.want, got)
+		}
+	}
+}
+
+func TestStyleEqual(t *testing.T) {
+	a := NewStyle("", RedPaint).WithAttributes(Italic).WithBackground(BluePaint).WithAttributes(Bold)
+	b := Background(BluePaint).WithAttributes(Bold, Italic).WithForeground("91")
+	if !a.Equal(b) || !b.Equal(a) {
+		t.Errorf("Want %v and %v equal", a, b)
+	}
+	if c := b.WithForeground(Index(196)); a.Equal(c) {
+		t.Errorf("Want %v and %v different", a, c)
+	}
+	if c := a.WithAttributes(Underline); a.Equal(c) {
+		t.Errorf("Want %v and %v different", a, c)
+	}
+	if c := a.WithRaw("53"); a.Equal(c) {
+		t.Errorf("Want %v and %v different", a, c)
+	}
+	red, bright := NewStyle("", RedPaint), NewStyle("", "91")
+	if red.Equal(bright) || bright.Equal(red) {
+		t.Errorf("Want %v and %v different", red, bright)
+	}
+	if bold := bright.WithAttributes(Bold); !red.Equal(bold) {
+		t.Errorf("Want %v and %v equal", red, bold)
+	}
+}
+
 var allPaints = []struct {
 	color string
 	p     Paint
@@ -117,8 +189,8 @@ func TestAllPermutationsOfPaint(t *testing.T) {
 		brush := NewBrush(perm.bg, perm.fg)
 
 		want := "" +
-			"\033[" + "4" + string(perm.bg[len(perm.bg)-1]) + "m" +
 			"\033[" + string(perm.fg) + "m" +
+			"\033[" + "4" + string(perm.bg[len(perm.bg)-1]) + "m" +
 			perm.name + "\033[0m"
 
 		got := brush(perm.name)
@@ -129,3 +201,553 @@ func TestAllPermutationsOfPaint(t *testing.T) {
 		}
 	}
 }
+
+func TestDarkForegroundKeepsBackground(t *testing.T) {
+	for _, fg := range []Paint{BlackPaint, DarkRedPaint, LightGrayPaint} {
+		style := NewStyle(BluePaint, fg)
+
+		var look Style
+		eachToken(style.code, func(tok string, _ bool) {
+			if params, ok := sgrParams([]byte(tok)); ok {
+				look = applySGR(look, params)
+			}
+		})
+		if look.bg != "34" || look.fg.Normalize() != fg {
+			t.Errorf("%#v on blue: Want the background and the foreground, got %#v", fg, look)
+		}
+	}
+}
+
+func TestBrushNonEmpty(t *testing.T) {
+	brush := NewStyle("", RedPaint).BrushNonEmpty()
+
+	if got := brush(""); got != "" {
+		t.Errorf("Want %#v, got %#v", "", got)
+	}
+
+	want := NewBrush("", RedPaint)("red")
+	if got := brush("red"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestTrimmed(t *testing.T) {
+	style := NewStyle(DarkBluePaint, WhitePaint)
+	brush := style.Trimmed()
+
+	for _, test := range []struct {
+		text, want string
+	}{
+		{"  42 \t", "  " + style.Brush()("42") + " \t"},
+		{"a b", style.Brush()("a b")},
+		{"\n x", "\n " + style.Brush()("x")},
+		{"   ", "   "},
+		{"", ""},
+	} {
+		if got := brush(test.text); got != test.want {
+			t.Errorf("%q: Want %#v, got %#v", test.text, test.want, got)
+		}
+	}
+}
+
+func TestPerLine(t *testing.T) {
+	style := NewStyle(DarkBluePaint, WhitePaint)
+	line := style.Brush()
+	brush := style.PerLine()
+
+	for _, test := range []struct {
+		text, want string
+	}{
+		{"one\ntwo\nthree", line("one") + "\n" + line("two") + "\n" + line("three")},
+		{"one\n\nthree\n", line("one") + "\n\n" + line("three") + "\n"},
+		{"single", line("single")},
+		{"", ""},
+	} {
+		if got := brush(test.text); got != test.want {
+			t.Errorf("%q: Want %#v, got %#v", test.text, test.want, got)
+		}
+	}
+}
+
+func TestBrightBackground(t *testing.T) {
+	for _, p := range []Paint{RedPaint, DarkRedPaint} {
+		brush := NewStyle("", WhitePaint).WithBrightBackground(p).Brush()
+
+		want := "\033[" + string(WhitePaint) + "m" + "\033[101m" + "text" + "\033[0m"
+		if got := brush("text"); got != want {
+			t.Errorf("Want %#v, got %#v", want, got)
+		}
+	}
+
+	brush := NewStyle(RedPaint, WhitePaint).WithBrightBackground("").Brush()
+	want := NewBrush("", WhitePaint)("text")
+	if got := brush("text"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// paints without a bright variant are kept as they are
+	for _, test := range []struct {
+		p    Paint
+		want string
+	}{
+		{PaintRGB(1, 2, 3), "\033[48;2;1;2;3m"},
+		{Index(200), "\033[48;5;200m"},
+		{"39", ""},
+		{"94", "\033[104m"},
+	} {
+		want := "\033[" + string(WhitePaint) + "m" + test.want + "text" + "\033[0m"
+		if got := NewStyle("", WhitePaint).WithBrightBackground(test.p).Brush()("text"); got != want {
+			t.Errorf("%#v: Want %#v, got %#v", test.p, want, got)
+		}
+	}
+}
+
+func TestIsolated(t *testing.T) {
+	style := NewStyle(BlackPaint, YellowPaint)
+
+	want := "\033[0m" + style.Brush()("isolated")
+	if got := style.Isolated()("isolated"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	red, blue := NewStyle("", RedPaint), NewStyle("", BluePaint)
+	const r, b, g, reset = "\033[1;31m", "\033[1;34m", "\033[1;32m", "\033[0m"
+
+	got := red.Wrap("error: " + blue.Wrap("in "+Green("file")+" main.go") + " not found")
+	want := r + "error: " + b + "in " + g + "file" + reset + r + b + " main.go" + reset + r + " not found" + reset
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// the style is re-applied after the resets, not after the last one
+	want = r + "plain" + reset
+	if got := red.Wrap("plain"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	want = r + Green("ok") + reset
+	if got := red.Wrap(Green("ok")); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	// sequences setting attributes aren't resets, even with the default
+	// colors
+	want = r + "a\033[3;39;49mb" + reset
+	if got := red.Wrap("a\033[3;39;49mb"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// the nested brushes end with their own off codes with ResetColors
+	SetResetMode(ResetColors)
+	want = r + "a" + Blue("b") + r + "c" + red.reset()
+	got = red.Wrap("a" + Blue("b") + "c")
+	SetResetMode(ResetAll)
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	Disable()
+	defer Enable()
+	if got := red.Wrap("x"); got != "x" {
+		t.Errorf("Want %#v, got %#v", "x", got)
+	}
+}
+
+func TestBackground(t *testing.T) {
+	want := "\033[43m" + "highlighted" + "\033[0m"
+	if got := Background(DarkYellowPaint).Brush()("highlighted"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want = "\033[48;2;1;2;3m" + "highlighted" + "\033[0m"
+	if got := Background(PaintRGB(1, 2, 3)).Brush()("highlighted"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	if got := Background(BluePaint).Foreground(); got != "" {
+		t.Errorf("Want no foreground, got %#v", got)
+	}
+}
+
+func TestFillLine(t *testing.T) {
+	style := NewStyle(DarkBluePaint, WhitePaint)
+
+	got := style.FillLine("build " + Green("ok"))
+	want := style.code + "build " + Green("ok") + style.code + "\033[K" + "\033[0m"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// the background must be set again between the text's reset and the
+	// erase
+	reset, erase := strings.LastIndex(got, Green("ok")), strings.Index(got, "\033[K")
+	if code := strings.LastIndex(got, style.code); code < reset || code > erase {
+		t.Errorf("Want the style between the text and the erase, got %#v", got)
+	}
+
+	Disable()
+	defer Enable()
+	if got := style.FillLine("plain"); got != "plain" {
+		t.Errorf("Want %#v, got %#v", "plain", got)
+	}
+}
+
+func TestBrushAll(t *testing.T) {
+	style := NewStyle("", GreenPaint)
+	parts := []string{"a", "b", "c"}
+
+	all := style.BrushAll(parts...)
+	if want := style.Brush()("abc"); all != want {
+		t.Errorf("Want %#v, got %#v", want, all)
+	}
+
+	var each string
+	for _, part := range parts {
+		each += style.Brush()(part)
+	}
+	if n, perPart := strings.Count(all, "\033"), strings.Count(each, "\033"); n != 2 || perPart != 6 {
+		t.Errorf("Want 2 escapes instead of 6, got %d instead of %d", n, perPart)
+	}
+
+	if got := style.BrushAll(); got != "" {
+		t.Errorf("Want %#v, got %#v", "", got)
+	}
+}
+
+func TestAppendTo(t *testing.T) {
+	segments := []struct {
+		style Style
+		text  string
+	}{
+		{NewStyle("", RedPaint), "error"},
+		{Style{}, ": "},
+		{NewStyle(DarkBluePaint, WhitePaint), "disk full"},
+		{NewStyle("", DarkGrayPaint), ""},
+	}
+
+	var b strings.Builder
+	want := ""
+	for _, seg := range segments {
+		seg.style.AppendTo(&b, seg.text)
+		want += seg.style.Brush()(seg.text)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	style := NewStyle("", YellowPaint)
+	warnf := style.Formatter("WARN: %s (%d%%)")
+
+	want := style.Brush()("WARN: disk almost full (93%)")
+	if got := warnf("disk almost full", 93); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	want := Yellow("retry 2 of 5")
+	if got := Yellow.Sprintf("retry %d of %d", 2, 5); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if got := NewStyle("", YellowPaint).Sprintf("retry %d of %d", 2, 5); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestFprintf(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := Red.Fprintf(&buf, "%s failed", "build")
+
+	want := Red("build failed")
+	if got := buf.String(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if n != len(want) || err != nil {
+		t.Errorf("Want (%d, <nil>), got (%d, %v)", len(want), n, err)
+	}
+
+	if _, err := Red.Fprintf(failingWriter{}, "%s failed", "build"); err == nil || err.Error() != "disk full" {
+		t.Errorf("Want the write error, got %v", err)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	for _, test := range []struct {
+		parts []interface{}
+		want  string
+	}{
+		{[]interface{}{RedPaint}, "\033[1;31m"},
+		{[]interface{}{WhitePaint, Background(DarkRedPaint), Bold}, "\033[1;37;41m"},
+		{[]interface{}{Underline, DarkBluePaint, Italic}, "\033[0;34;3;4m"},
+		{[]interface{}{NewStyle(BluePaint, RedPaint).WithAttributes(Bold), GreenPaint}, "\033[1;32;44m"},
+		{[]interface{}{Index(208), Background(PaintRGB(1, 2, 3))}, "\033[38;5;208;48;2;1;2;3m"},
+		{[]interface{}{Style{}.WithRaw("53"), Reverse}, "\033[7;53m"},
+		{nil, ""},
+	} {
+		if got := Sequence(test.parts...); got != test.want {
+			t.Errorf("%#v: Want %#v, got %#v", test.parts, test.want, got)
+		}
+	}
+}
+
+func TestSequencePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Want a panic for a string part")
+		}
+	}()
+	Sequence(RedPaint, "bold")
+}
+
+func TestEmptyStyle(t *testing.T) {
+	for _, style := range []Style{{}, NewStyle("", "")} {
+		if got := style.Brush()("plain"); got != "plain" {
+			t.Errorf("%#v: Want %#v, got %#v", style, "plain", got)
+		}
+		if got := Overhead(style); got != 0 {
+			t.Errorf("%#v: Want no overhead, got %d", style, got)
+		}
+	}
+
+	want := "\033[3m" + "italic" + "\033[0m"
+	if got := NewStyle("", "").WithAttributes(Italic).Brush()("italic"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want = "\033[44m" + "blue" + "\033[0m"
+	if got := NewStyle(BluePaint, "").Brush()("blue"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestBrushLine(t *testing.T) {
+	style := NewStyle(DarkBluePaint, WhitePaint)
+
+	want := style.code + " 3 files changed" + "\033[K" + "\033[0m"
+	if got := style.BrushLine(" 3 files changed"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	Disable()
+	defer Enable()
+	if got := style.BrushLine("plain"); got != "plain" {
+		t.Errorf("Want %#v, got %#v", "plain", got)
+	}
+}
+
+func TestResetCode(t *testing.T) {
+	if got := ResetCode(); got != "\033[0m" {
+		t.Errorf("Want %#v, got %#v", "\033[0m", got)
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	style := NewStyle("", DarkGrayPaint)
+
+	got := style.Repeat('-', 10)
+	want := "\033[" + string(DarkGrayPaint) + "m" + "----------" + "\033[0m"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if n := strings.Count(got, "\033["); n != 2 {
+		t.Errorf("Want one code and one reset, got %d sequences", n)
+	}
+
+	want = style.Brush()("═══")
+	if got := style.Repeat('═', 3); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	for _, n := range []int{0, -1} {
+		if got := style.Repeat('-', n); got != "" {
+			t.Errorf("Want %#v, got %#v", "", got)
+		}
+	}
+}
+
+var backgroundCodeTT = []struct {
+	p    Paint
+	want string
+	ok   bool
+}{
+	{DarkRedPaint, "41", true},
+	{RedPaint, "41", true},
+	{"31", "41", true},
+	{"91", "101", true},
+	{PaintRGB(1, 2, 3), "48;2;1;2;3", true},
+	{"38;5;200", "48;5;200", true},
+	{"39", "", false},
+	{"", "", false},
+	{"bogus", "", false},
+}
+
+func TestBackgroundCode(t *testing.T) {
+	for _, test := range backgroundCodeTT {
+		got, ok := test.p.BackgroundCode()
+		if got != test.want || ok != test.ok {
+			t.Errorf("%#v: want (%#v, %v), got (%#v, %v)", test.p, test.want, test.ok, got, ok)
+		}
+	}
+}
+
+func TestMinimalCode(t *testing.T) {
+	// the look of a code on a terminal in its default state
+	look := func(code string) Style {
+		var state Style
+		eachToken(code, func(tok string, _ bool) {
+			if params, ok := sgrParams([]byte(tok)); ok {
+				state = applySGR(state, params)
+			}
+		})
+		return state
+	}
+
+	tests := []struct {
+		style Style
+		want  string
+	}{
+		{NewStyle("", DarkRedPaint), "\033[31m"},
+		{NewStyle("", RedPaint).WithAttributes(Bold), "\033[31;1m"},
+		{NewStyle(BluePaint, RedPaint), "\033[31;44;1m"},
+		{NewStyle(DarkBluePaint, Index(208)).WithAttributes(Italic), "\033[38;5;208;44;3m"},
+		{NewStyle(BluePaint, Paint("39")), "\033[44m"},
+		{NewStyle("", DarkGreenPaint).WithRaw("53"), "\033[32;53m"},
+		{Style{}, ""},
+	}
+	for _, tt := range tests {
+		got := tt.style.MinimalCode()
+		if got != tt.want {
+			t.Errorf("Want %#v, got %#v", tt.want, got)
+		}
+		if len(got) > len(tt.style.code) {
+			t.Errorf("Want at most %d bytes, got %d", len(tt.style.code), len(got))
+		}
+		if want, got := look(tt.style.code), look(got); got != want {
+			t.Errorf("Want %#v, got %#v", want, got)
+		}
+	}
+
+	Disable()
+	defer Enable()
+	if got := NewStyle("", RedPaint).MinimalCode(); got != "" {
+		t.Errorf("Want %#v, got %#v", "", got)
+	}
+}
+
+func TestOverhead(t *testing.T) {
+	style := NewStyle(BluePaint, RedPaint)
+
+	// \033[44m, \033[1;31m and \033[0m
+	if got := Overhead(style); got != 5+7+4 {
+		t.Errorf("Want %d, got %d", 5+7+4, got)
+	}
+	if got := len(style.Brush()("text")) - len("text"); got != Overhead(style) {
+		t.Errorf("Want %d, got %d", got, Overhead(style))
+	}
+
+	Disable()
+	defer Enable()
+	if got := Overhead(style); got != 0 {
+		t.Errorf("Want %d, got %d", 0, got)
+	}
+}
+
+func TestBufferedBrush(t *testing.T) {
+	style := NewStyle(DarkBluePaint, YellowPaint)
+	paint := style.BufferedBrush()
+
+	buf := paint([]byte("> "), "warning")
+	if want, got := "> "+style.Brush()("warning"), string(buf); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	buf = paint(buf[:0], "again")
+	if want, got := style.Brush()("again"), string(buf); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	Disable()
+	defer Enable()
+	if want, got := "plain", string(paint(nil, "plain")); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+var firstLastTT = []struct {
+	n           int
+	text        string
+	first, last string
+}{
+	{2, "main.go", "[ma]in.go", "main.[go]"},
+	{3, "héllo", "[hél]lo", "hé[llo]"},
+	{1, "日本語", "[日]本語", "日本[語]"},
+	{10, "short", "[short]", "[short]"},
+	{5, "short", "[short]", "[short]"},
+	{0, "none", "none", "none"},
+	{-1, "none", "none", "none"},
+	{3, "", "", ""},
+}
+
+func TestFirstLast(t *testing.T) {
+	style := NewStyle("", CyanPaint)
+	// [ and ] stand for the style's code and reset in the table
+	expand := strings.NewReplacer("[", style.code, "]", ResetCode()).Replace
+
+	for _, test := range firstLastTT {
+		if want, got := expand(test.first), style.First(test.n, test.text); got != want {
+			t.Errorf("First(%d, %q): Want %#v, got %#v", test.n, test.text, want, got)
+		}
+		if want, got := expand(test.last), style.Last(test.n, test.text); got != want {
+			t.Errorf("Last(%d, %q): Want %#v, got %#v", test.n, test.text, want, got)
+		}
+	}
+}
+
+func BenchmarkBrush(b *testing.B) {
+	paint := NewStyle("", RedPaint).Brush()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = paint("some text to paint")
+	}
+}
+
+func BenchmarkBufferedBrush(b *testing.B) {
+	paint := NewStyle("", RedPaint).BufferedBrush()
+	var buf []byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = paint(buf[:0], "some text to paint")
+	}
+}
+
+func TestWithRaw(t *testing.T) {
+	style := NewStyle("", DarkCyanPaint).WithAttributes(Bold).WithRaw("53")
+
+	want := "\033[0;36;1;53m" + "overlined" + "\033[0m"
+	if got := style.Brush()("overlined"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// raw parameters are kept in order, after the attributes
+	style = style.WithRaw("58;5;196").WithAttributes(Italic)
+	want = "\033[0;36;1;3;53;58;5;196m"
+	if got := style.code; got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestWithRawInvalid(t *testing.T) {
+	style := NewStyle("", DarkCyanPaint)
+	for _, params := range []string{"", "53;", ";53", "5a", "31m\033[2J", "4:3"} {
+		if got := style.WithRaw(params); got != style {
+			t.Errorf("%q: Want the style unchanged, got %#v", params, got)
+		}
+	}
+}
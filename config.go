@@ -0,0 +1,280 @@
+package color
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// settings holds the package wide configuration used when computing color
+// codes. Codes are computed once and cached in each Style, so changing a
+// setting only affects styles created afterwards.
+var settings = struct {
+	sync.RWMutex
+	compact bool
+	csi     string
+	metric  DistanceMetric
+}{
+	csi: pre,
+}
+
+// disabled is set when colors are disabled. It's checked each time a string
+// is painted, so it's kept out of the settings lock.
+var disabled int32
+
+// Disable turns colors off: brushes and styles then leave strings
+// unchanged.
+func Disable() {
+	atomic.StoreInt32(&disabled, 1)
+}
+
+// Enable turns colors back on, they are on by default.
+func Enable() {
+	atomic.StoreInt32(&disabled, 0)
+}
+
+// Enabled tells if colors are enabled, that is they weren't disabled and
+// the color level isn't LevelNone.
+func Enabled() bool {
+	return atomic.LoadInt32(&disabled) == 0 && atomic.LoadInt32(&colorLevel) != int32(LevelNone)
+}
+
+// WithDisabled runs f with colors disabled, and then restores whether they
+// were enabled, even if f panics.  It's handy to get plain output locally,
+// in tests for example.  Other goroutines are affected while f runs.
+func WithDisabled(f func()) {
+	old := atomic.SwapInt32(&disabled, 1)
+	defer atomic.StoreInt32(&disabled, old)
+	f()
+}
+
+// colorLevel is the level colors are computed for, see SetColorLevel.
+var colorLevel = int32(LevelTrueColor)
+
+// SetColorLevel sets the level of the colors the terminal displays,
+// LevelTrueColor by default.  Paints beyond it are replaced by the nearest
+// color the level has, and nothing is painted at LevelNone.  Only styles
+// created after the call are affected, but the brushes of this package
+// create theirs each time they paint.
+func SetColorLevel(level Level) {
+	atomic.StoreInt32(&colorLevel, int32(level))
+}
+
+// ColorLevel gives the level set with SetColorLevel.
+func ColorLevel() Level {
+	return Level(atomic.LoadInt32(&colorLevel))
+}
+
+// WithLevel runs f with the color level set to level, and then restores the
+// previous one, even if f panics.
+func WithLevel(level Level, f func()) {
+	old := atomic.SwapInt32(&colorLevel, int32(level))
+	defer atomic.StoreInt32(&colorLevel, old)
+	f()
+}
+
+// ResetMode is how much of the terminal's state the reset after painted
+// text clears.
+type ResetMode int32
+
+// Reset modes, ResetAll by default.
+const (
+	// ResetAll clears everything with `\033[0m`.
+	ResetAll ResetMode = iota
+	// ResetColors only restores the default foreground and background with
+	// `\033[39;49m`, keeping the attributes set around the painted text.
+	// The attributes set by the style itself, including the bold of the
+	// bright paint constants, are turned off along, such as with
+	// `\033[22;39;49m` after RedPaint.
+	ResetColors
+)
+
+// resetMode is the ResetMode in use, kept out of the settings lock like
+// disabled since it's read each time a string is painted.
+var resetMode int32
+
+// SetResetMode changes the reset emitted after painted text, and given by
+// ResetCode.  It applies to all the styles from the call on.
+func SetResetMode(m ResetMode) {
+	atomic.StoreInt32(&resetMode, int32(m))
+}
+
+// resetSeq gives the sequence of the current ResetMode.
+func resetSeq() string {
+	if ResetMode(atomic.LoadInt32(&resetMode)) == ResetColors {
+		return sgr("39;49")
+	}
+	return sgr("0")
+}
+
+// reset gives the sequence ending text painted with the style, in the
+// current ResetMode.
+func (s Style) reset() string {
+	if ResetMode(atomic.LoadInt32(&resetMode)) == ResetColors {
+		return s.seq(joinParams(strings.Join(s.attributeOffs(), ";"), "39;49"), 'm')
+	}
+	return s.seq("0", 'm')
+}
+
+// CompactCodes toggles the compact form of the dark paints. When enabled,
+// the redundant leading `0;` of paints such as DarkRedPaint is dropped, so
+// they emit `\033[31m` instead of `\033[0;31m`.  Only styles created after
+// the call are affected.
+func CompactCodes(enabled bool) {
+	settings.Lock()
+	settings.compact = enabled
+	settings.Unlock()
+}
+
+// SetCSI changes the control sequence introducer starting every sequence,
+// `\033[` by default, for the few terminals that need another one, such as
+// the 8-bit `\233`.  Only styles created after the call are affected: the
+// styles made before keep the introducer of their code for their resets
+// too, so their sequences stay consistent.  The package level brushes such
+// as Red build their style when painting, and follow the change.
+func SetCSI(prefix string) {
+	settings.Lock()
+	settings.csi = prefix
+	settings.Unlock()
+}
+
+// Use8BitCSI toggles the single byte control sequence introducer `\233`,
+// saving a byte per sequence over the default `\033[` on the terminals
+// and links that understand it.  It is the same as SetCSI with either one,
+// so only styles created after the call are affected.
+func Use8BitCSI(enabled bool) {
+	if enabled {
+		SetCSI("\233")
+	} else {
+		SetCSI(pre)
+	}
+}
+
+// Option is a setting given to Configure.
+type Option func(*config)
+
+// config is the whole configuration Configure works on.
+type config struct {
+	disabled bool
+	level    Level
+	reset    ResetMode
+	compact  bool
+	csi      string
+	metric   DistanceMetric
+}
+
+// DisabledOption disables colors when true and enables them when false,
+// like Disable and Enable.
+func DisabledOption(disabled bool) Option {
+	return func(c *config) { c.disabled = disabled }
+}
+
+// LevelOption sets the color level, like SetColorLevel.
+func LevelOption(level Level) Option {
+	return func(c *config) { c.level = level }
+}
+
+// ResetOption changes the reset after painted text, like SetResetMode.
+func ResetOption(m ResetMode) Option {
+	return func(c *config) { c.reset = m }
+}
+
+// CompactOption toggles the compact form of the dark paints, like
+// CompactCodes.
+func CompactOption(enabled bool) Option {
+	return func(c *config) { c.compact = enabled }
+}
+
+// CSIOption changes the control sequence introducer, like SetCSI, for the
+// styles created after Configure.
+func CSIOption(prefix string) Option {
+	return func(c *config) { c.csi = prefix }
+}
+
+// MetricOption changes the distance metric, like SetDistanceMetric.
+func MetricOption(m DistanceMetric) Option {
+	return func(c *config) { c.metric = m }
+}
+
+// Configure applies all the options at once, in order, in place of a
+// series of setters, i.e. when the program starts:
+//
+//	color.Configure(color.LevelOption(color.Level256), color.CSIOption("\233"))
+//
+// The options are named after their setting, DisabledOption rather than
+// WithDisabled for instance, since WithDisabled and WithLevel already run a
+// function with a setting changed.
+//
+// Concurrent calls to Configure don't mix their settings: each one sees and
+// leaves a consistent configuration.  Only the compact, CSI and metric
+// settings are applied together for the code painting, though: whether
+// colors are enabled, the level and the reset mode are read without the
+// lock each time a string is painted, so a string painted while Configure
+// runs may see some of them changed and not the others yet.  Settings not
+// given are kept.  As with the setters, only styles created afterwards see
+// the changes to their codes.
+func Configure(opts ...Option) {
+	settings.Lock()
+	defer settings.Unlock()
+
+	c := config{
+		disabled: atomic.LoadInt32(&disabled) != 0,
+		level:    Level(atomic.LoadInt32(&colorLevel)),
+		reset:    ResetMode(atomic.LoadInt32(&resetMode)),
+		compact:  settings.compact,
+		csi:      settings.csi,
+		metric:   settings.metric,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	settings.compact, settings.csi, settings.metric = c.compact, c.csi, c.metric
+	atomic.StoreInt32(&colorLevel, int32(c.level))
+	atomic.StoreInt32(&resetMode, int32(c.reset))
+	if c.disabled {
+		atomic.StoreInt32(&disabled, 1)
+	} else {
+		atomic.StoreInt32(&disabled, 0)
+	}
+}
+
+// csi gives the control sequence with the given parameters and final
+// byte, using the current control sequence introducer.
+func csi(params string, final byte) string {
+	return currentCSI() + params + string(final) + post
+}
+
+// currentCSI gives the control sequence introducer in use.
+func currentCSI() string {
+	settings.RLock()
+	defer settings.RUnlock()
+	return settings.csi
+}
+
+// sgr gives the SGR sequence with the given parameters.
+func sgr(params string) string {
+	return csi(params, 'm')
+}
+
+// seq gives the control sequence with the given parameters and final
+// byte, using the introducer the style's code was computed with, so that
+// the codes and resets of a style made before a SetCSI still match.
+func (s Style) seq(params string, final byte) string {
+	if s.csi == "" {
+		return csi(params, final)
+	}
+	return s.csi + params + string(final) + post
+}
+
+// compactPaint strips the leading `0;` of a paint when compact codes are
+// enabled.
+func compactPaint(p Paint) Paint {
+	settings.RLock()
+	compact := settings.compact
+	settings.RUnlock()
+	if !compact {
+		return p
+	}
+	return Paint(strings.TrimPrefix(string(p), "0;"))
+}
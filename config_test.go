@@ -0,0 +1,268 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestCompactCodes(t *testing.T) {
+	defer CompactCodes(false)
+
+	long := NewBrush("", DarkRedPaint)("red")
+
+	CompactCodes(true)
+	short := NewBrush("", DarkRedPaint)("red")
+
+	want := "\033[31m" + "red" + "\033[0m"
+	if short != want {
+		t.Errorf("Want %#v, got %#v", want, short)
+	}
+
+	if len(long)-len(short) != len("0;") {
+		t.Errorf("Want compact form to save %d bytes, saved %d", len("0;"), len(long)-len(short))
+	}
+
+	// bright paints have no redundant prefix and are left as is
+	want = NewBrush("", RedPaint)("red")
+	CompactCodes(false)
+	if got := NewBrush("", RedPaint)("red"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestSetCSI(t *testing.T) {
+	defer SetCSI("\033[")
+
+	SetCSI("\233")
+	want := "\233" + "0;34m" + "\233" + "41m" + "text" + "\233" + "0m"
+	if got := NewBrush(DarkRedPaint, DarkBluePaint)("text"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	SetCSI("\033[")
+	want = "\033[" + "0;34m" + "\033[" + "41m" + "text" + "\033[" + "0m"
+	if got := NewBrush(DarkRedPaint, DarkBluePaint)("text"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestSetCSIKeepsStyles(t *testing.T) {
+	defer SetCSI("\033[")
+
+	style := NewStyle("", RedPaint)
+	SetCSI("\233")
+	want := "\x1b[1;31mx\x1b[0m"
+	if got := style.Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	want = "\x1b[1;31mx\x1b[K\x1b[0m"
+	if got := style.BrushLine("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	want = "\233" + "1;31m" + "x" + "\233" + "0m"
+	if got := Red("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestDisable(t *testing.T) {
+	Disable()
+	defer Enable()
+
+	if Enabled() {
+		t.Errorf("Want colors disabled")
+	}
+	if got := Red("x"); got != "x" {
+		t.Errorf("Want %#v, got %#v", "x", got)
+	}
+	if got := Red.Bold()("x"); got != "x" {
+		t.Errorf("Want %#v, got %#v", "x", got)
+	}
+	if got := NewStyle("", RedPaint).Isolated()("x"); got != "x" {
+		t.Errorf("Want %#v, got %#v", "x", got)
+	}
+
+	Enable()
+	if want, got := "\033[1;31mx\033[0m", Red("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestUse8BitCSI(t *testing.T) {
+	defer Use8BitCSI(false)
+
+	Use8BitCSI(true)
+	want := "\x9b" + "0;34m" + "text" + "\x9b" + "K" + "\x9b" + "0m"
+	if got := NewStyle("", DarkBluePaint).BrushLine("text"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	Use8BitCSI(false)
+	want = "\x1b[" + "0;34m" + "text" + "\x1b[" + "K" + "\x1b[" + "0m"
+	if got := NewStyle("", DarkBluePaint).BrushLine("text"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestWithDisabled(t *testing.T) {
+	WithDisabled(func() {
+		if got := Red("x"); got != "x" {
+			t.Errorf("Want %#v, got %#v", "x", got)
+		}
+	})
+	if !Enabled() {
+		t.Errorf("Want colors enabled again after WithDisabled")
+	}
+
+	Disable()
+	WithDisabled(func() {})
+	if Enabled() {
+		t.Errorf("Want colors still disabled after WithDisabled")
+	}
+	Enable()
+}
+
+func TestWithDisabledPanic(t *testing.T) {
+	func() {
+		defer func() { recover() }()
+		WithDisabled(func() { panic("boom") })
+	}()
+	if !Enabled() {
+		t.Errorf("Want colors enabled again after a panic")
+	}
+}
+
+func TestWithLevel(t *testing.T) {
+	style := func() Style { return NewStyle("", PaintRGB(250, 10, 10)) }
+
+	WithLevel(Level256, func() {
+		if want, got := "\033[38;5;196mx\033[0m", style().Brush()("x"); got != want {
+			t.Errorf("Want %#v, got %#v", want, got)
+		}
+	})
+	WithLevel(Level16, func() {
+		if want, got := Red("x"), style().Brush()("x"); got != want {
+			t.Errorf("Want %#v, got %#v", want, got)
+		}
+	})
+	WithLevel(LevelNone, func() {
+		if got := Red("x"); got != "x" {
+			t.Errorf("Want %#v, got %#v", "x", got)
+		}
+	})
+
+	if ColorLevel() != LevelTrueColor {
+		t.Errorf("Want level %d restored, got %d", LevelTrueColor, ColorLevel())
+	}
+	func() {
+		defer func() { recover() }()
+		WithLevel(Level16, func() { panic("boom") })
+	}()
+	if ColorLevel() != LevelTrueColor {
+		t.Errorf("Want level %d restored after a panic, got %d", LevelTrueColor, ColorLevel())
+	}
+}
+
+func TestSetColorLevel(t *testing.T) {
+	defer SetColorLevel(LevelTrueColor)
+	style := func() Style { return NewStyle(PaintRGB(10, 10, 230), PaintRGB(250, 10, 10)) }
+
+	SetColorLevel(Level16)
+	if want, got := "\033[1;31m\033[44mx\033[0m", style().Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	SetColorLevel(LevelTrueColor)
+	if want, got := "\033[38;2;250;10;10m\033[48;2;10;10;230mx\033[0m", style().Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	defer Configure(DisabledOption(false), LevelOption(LevelTrueColor), CompactOption(false), CSIOption("\033["))
+
+	Configure(LevelOption(Level256), CSIOption("\233"), CompactOption(true))
+	want := "\233" + "38;5;196m" + "x" + "\233" + "0m"
+	if got := NewStyle("", PaintRGB(250, 10, 10)).Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	want = "\233" + "31m" + "x" + "\233" + "0m"
+	if got := DarkRed("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// later options win, and settings not given are kept
+	Configure(DisabledOption(true), DisabledOption(false), LevelOption(Level16))
+	if !Enabled() || ColorLevel() != Level16 {
+		t.Errorf("Want colors enabled at level %d, got %v at %d", Level16, Enabled(), ColorLevel())
+	}
+	if got := DarkRed("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	Configure(DisabledOption(true))
+	if got := DarkRed("x"); got != "x" {
+		t.Errorf("Want %#v, got %#v", "x", got)
+	}
+}
+
+func TestSetResetMode(t *testing.T) {
+	defer SetResetMode(ResetAll)
+
+	style := NewStyle("", DarkRedPaint)
+	if want, got := "\033[0;31mx\033[0m", style.Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	SetResetMode(ResetColors)
+	if want, got := "\033[0;31mx\033[39;49m", style.Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := "\033[39;49m", ResetCode(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := "\033[0;31mx\033[39;49m", string(style.BufferedBrush()(nil, "x")); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	Configure(ResetOption(ResetAll))
+	if want, got := "\033[0;31mx\033[0m", style.Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestResetColorsAttributes(t *testing.T) {
+	defer SetResetMode(ResetAll)
+	SetResetMode(ResetColors)
+
+	for _, test := range []struct {
+		painted string
+		want    string
+	}{
+		{Red("x"), "\033[1;31mx\033[22;39;49m"},
+		{NewStyle("", DarkRedPaint).WithAttributes(Italic, Underline).Brush()("x"), "\033[0;31;3;4mx\033[23;24;39;49m"},
+		{Background(DarkBluePaint).WithAttributes(Reverse).Brush()("x"), "\033[7m\033[44mx\033[27;39;49m"},
+	} {
+		if test.painted != test.want {
+			t.Errorf("Want %#v, got %#v", test.want, test.painted)
+		}
+
+		// the text after the painted one is back to plain
+		var after Style
+		eachToken(test.painted+"after", func(tok string, escape bool) {
+			if params, ok := sgrParams([]byte(tok)); ok {
+				after = applySGR(after, params)
+			}
+		})
+		if after != (Style{}) {
+			t.Errorf("%#v: Want plain text after it, got %#v", test.painted, after)
+		}
+		if !IsBalanced(test.painted) {
+			t.Errorf("%#v: Want balanced", test.painted)
+		}
+	}
+
+	// text around the painted one keeps its own attributes
+	outer := "\033[3m" + "a" + NewStyle("", "31").Brush()("x") + "b"
+	if want := "\033[3ma\033[31mx\033[39;49mb"; outer != want {
+		t.Errorf("Want %#v, got %#v", want, outer)
+	}
+}
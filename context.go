@@ -0,0 +1,45 @@
+package color
+
+import (
+	"context"
+)
+
+// colorKey is the context key of the setting given with WithColor.
+type colorKey struct{}
+
+// WithColor gives a copy of ctx telling whether to paint, for settings that
+// vary from one request to the next, such as a `?color=off` parameter:
+//
+//	ctx = color.WithColor(ctx, r.URL.Query().Get("color") != "off")
+//
+// The brushes of BrushContext follow it rather than Disable and Enable.
+func WithColor(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, colorKey{}, enabled)
+}
+
+// FromContext tells whether to paint according to ctx, and defaults to
+// Enabled when WithColor wasn't used on it.
+func FromContext(ctx context.Context) (enabled bool) {
+	if enabled, ok := ctx.Value(colorKey{}).(bool); ok {
+		return enabled
+	}
+	return Enabled()
+}
+
+// BrushContext is like Brush, but the returned Brush paints according to
+// FromContext(ctx) instead of the package wide switch, so that concurrent
+// requests can each have their own, i.e:
+//
+//	errorf := color.NewStyle("", color.RedPaint).BrushContext(ctx)
+//	fmt.Fprintln(w, errorf("not found"))
+//
+// The color level is still the package wide one.
+func (s Style) BrushContext(ctx context.Context) Brush {
+	enabled := FromContext(ctx)
+	return func(text string) string {
+		if !enabled || s.code == "" {
+			return text
+		}
+		return s.code + text + s.reset()
+	}
+}
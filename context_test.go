@@ -0,0 +1,50 @@
+package color
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	ctx := context.Background()
+	if !FromContext(ctx) {
+		t.Errorf("Want colors enabled by default")
+	}
+	if FromContext(WithColor(ctx, false)) {
+		t.Errorf("Want colors disabled by the context")
+	}
+
+	WithDisabled(func() {
+		if FromContext(ctx) {
+			t.Errorf("Want the package wide setting without WithColor")
+		}
+		if !FromContext(WithColor(ctx, true)) {
+			t.Errorf("Want colors enabled by the context")
+		}
+	})
+}
+
+func TestBrushContext(t *testing.T) {
+	style := NewStyle("", RedPaint)
+	on := WithColor(context.Background(), true)
+	off := WithColor(context.Background(), false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if want, got := style.Brush()("x"), style.BrushContext(on)("x"); got != want {
+				t.Errorf("Want %#v, got %#v", want, got)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if got := style.BrushContext(off)("x"); got != "x" {
+				t.Errorf("Want %#v, got %#v", "x", got)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,98 @@
+package color
+
+import (
+	"math"
+)
+
+// Luminance gives the relative luminance of the paint, as defined by WCAG,
+// from 0 for black to 1 for white.  ok is false if the paint has no RGB
+// value, such as the empty paint.
+func Luminance(p Paint) (l float64, ok bool) {
+	r, g, b, ok := p.RGB()
+	if !ok {
+		return 0, false
+	}
+	return 0.2126*linear(r) + 0.7152*linear(g) + 0.0722*linear(b), true
+}
+
+// linear converts an sRGB component to linear light: sRGB values are gamma
+// encoded, so they must be expanded before they can be weighted and summed.
+func linear(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.03928 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// ReadableForeground gives black or bright white, whichever contrasts most
+// with the background: white below a luminance of 0.179, black above.  The
+// paints are the `30` and `97` forms, which keep the background when they
+// follow it.
+func ReadableForeground(bg Paint) Paint {
+	if l, ok := Luminance(bg); ok && l < 0.179 {
+		return "97"
+	}
+	return "30"
+}
+
+// OnBackground gives you a style with the background and the foreground
+// reading best on it, i.e. to label colored cells:
+//
+//	fmt.Println(color.OnBackground(color.Heatmap(ms, 0, 500)).Brush()(label))
+func OnBackground(bg Paint) Style {
+	return NewStyle(bg, ReadableForeground(bg))
+}
+
+// minContrast is the contrast ratio AdaptForBackground brings foregrounds
+// to, the WCAG minimum for large text.
+const minContrast = 3
+
+// AdaptForBackground gives you the style with a foreground readable on the
+// background, the style's own or else the terminal's, dark or light as
+// reported by TerminalBackgroundIsDark:
+//
+//	dark, _ := color.TerminalBackgroundIsDark()
+//	link := color.AdaptForBackground(color.NewStyle("", color.DarkBluePaint), dark)
+//
+// A foreground with a contrast ratio below 3 is lightened toward white on
+// dark backgrounds and darkened toward black on light ones, just as much as
+// needed, into a truecolor paint.  Readable styles and styles without an RGB
+// foreground are left alone.
+func AdaptForBackground(s Style, darkBg bool) Style {
+	fl, ok := Luminance(s.fg)
+	if !ok {
+		return s
+	}
+	bl, ok := Luminance(s.bg)
+	if !ok {
+		bl = 1
+		if darkBg {
+			bl = 0
+		}
+	}
+	if contrastRatio(fl, bl) >= minContrast {
+		return s
+	}
+
+	toward := Paint("30")
+	if bl < 0.5 {
+		toward = "97"
+	}
+	for t := 0.1; t < 1; t += 0.1 {
+		fg := Blend(s.fg, toward, t)
+		if l, _ := Luminance(fg); contrastRatio(l, bl) >= minContrast {
+			return s.WithForeground(fg)
+		}
+	}
+	return s.WithForeground(toward)
+}
+
+// contrastRatio gives the WCAG contrast ratio of two luminances, from 1 for
+// the same luminance to 21 for black and white.
+func contrastRatio(a, b float64) float64 {
+	if a < b {
+		a, b = b, a
+	}
+	return (a + 0.05) / (b + 0.05)
+}
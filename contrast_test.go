@@ -0,0 +1,107 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+var luminanceTT = []struct {
+	p    Paint
+	want float64
+	ok   bool
+}{
+	{WhitePaint, 1, true},
+	{BlackPaint, 0, true},
+	{PaintRGB(255, 0, 0), 0.2126, true},
+	{PaintRGB(128, 128, 128), 0.2159, true},
+	{"", 0, false},
+}
+
+func TestLuminance(t *testing.T) {
+	for _, test := range luminanceTT {
+		got, ok := Luminance(test.p)
+		if ok != test.ok || math.Abs(got-test.want) > 0.001 {
+			t.Errorf("%#v: want (%v, %v), got (%v, %v)", test.p, test.want, test.ok, got, ok)
+		}
+	}
+}
+
+var readableTT = []struct {
+	bg   Paint
+	want Paint
+}{
+	{BlackPaint, "97"},
+	{DarkBluePaint, "97"},
+	{PaintRGB(40, 40, 40), "97"},
+	{WhitePaint, "30"},
+	{YellowPaint, "30"},
+	{Gray(20), "30"},
+	{"", "30"},
+}
+
+func TestReadableForeground(t *testing.T) {
+	for _, test := range readableTT {
+		if got := ReadableForeground(test.bg); got != test.want {
+			t.Errorf("%#v: Want %#v, got %#v", test.bg, test.want, got)
+		}
+	}
+}
+
+func TestOnBackground(t *testing.T) {
+	want := "\033[97m" + "\033[44m" + "label" + "\033[0m"
+	if got := OnBackground(DarkBluePaint).Brush()("label"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want = "\033[30m" + "\033[43m" + "label" + "\033[0m"
+	if got := OnBackground(YellowPaint).Brush()("label"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestAdaptForBackground(t *testing.T) {
+	contrast := func(s Style, darkBg bool) float64 {
+		fl, _ := Luminance(s.fg)
+		bl, ok := Luminance(s.bg)
+		if !ok && !darkBg {
+			bl = 1
+		}
+		return contrastRatio(fl, bl)
+	}
+
+	unreadable := []struct {
+		style  Style
+		darkBg bool
+	}{
+		{NewStyle("", DarkBluePaint), true},
+		{NewStyle("", YellowPaint).WithAttributes(Bold), false},
+		{NewStyle(DarkBluePaint, BluePaint), true},
+	}
+	for _, tt := range unreadable {
+		got := AdaptForBackground(tt.style, tt.darkBg)
+		if got.fg == tt.style.fg {
+			t.Errorf("Want %#v adjusted, got it unchanged", tt.style.fg)
+		}
+		if c := contrast(got, tt.darkBg); c < minContrast {
+			t.Errorf("Want a contrast of at least %v, got %v", minContrast, c)
+		}
+		if got.bg != tt.style.bg || got.attrs != tt.style.attrs {
+			t.Errorf("Want %#v, got %#v", tt.style, got)
+		}
+	}
+
+	readable := []struct {
+		style  Style
+		darkBg bool
+	}{
+		{NewStyle("", YellowPaint), true},
+		{NewStyle("", DarkBluePaint), false},
+		{NewStyle(YellowPaint, DarkBluePaint), true},
+		{Background(BluePaint), true},
+	}
+	for _, tt := range readable {
+		if got := AdaptForBackground(tt.style, tt.darkBg); got != tt.style {
+			t.Errorf("Want %#v, got %#v", tt.style, got)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package color
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// Demo gives you a sample of the colors of the terminal, for a `--demo`
+// flag or to check a theme: the 16 colors as foregrounds on each of them as
+// backgrounds, and the 256 colors palette below them when the color level
+// allows it.
+func Demo() string {
+	var buf bytes.Buffer
+
+	// the header names the foregrounds by their number
+	buf.WriteString(fmt.Sprintf("%-11s", ""))
+	for fg := range palette {
+		buf.WriteString(fmt.Sprintf(" %-3d", fg))
+	}
+	buf.WriteByte('\n')
+
+	for bg := -1; bg < len(palette); bg++ {
+		var back Paint
+		label := "default"
+		if bg >= 0 {
+			back, label = ansiPaint(bg), palette[bg].name
+		}
+		buf.WriteString(fmt.Sprintf("%-11s", label))
+		for fg := range palette {
+			buf.WriteString(NewStyle(back, ansiPaint(fg)).colorize(" Aa "))
+		}
+		buf.WriteByte('\n')
+	}
+
+	if ColorLevel() < Level256 {
+		return buf.String()
+	}
+	buf.WriteByte('\n')
+	for row := 0; row < 6; row++ {
+		for i := 16 + row*36; i < 16+(row+1)*36; i++ {
+			buf.WriteString(Swatch(Index(uint8(i))))
+		}
+		buf.WriteByte('\n')
+	}
+	for i := 232; i < 256; i++ {
+		buf.WriteString(Swatch(Index(uint8(i))))
+	}
+	buf.WriteByte('\n')
+	return buf.String()
+}
+
+// ansiPaint gives the i-th of the 16 colors as a paint without a `0;` or
+// `1;` prefix, which would reset the background or add bold.
+func ansiPaint(i int) Paint {
+	if i < 8 {
+		return Paint(strconv.Itoa(30 + i))
+	}
+	return Paint(strconv.Itoa(90 + i - 8))
+}
@@ -0,0 +1,35 @@
+package color
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDemo(t *testing.T) {
+	demo := Demo()
+
+	for i := 0; i < 8; i++ {
+		for _, code := range []string{
+			fmt.Sprintf("\033[3%dm", i), fmt.Sprintf("\033[9%dm", i),
+			fmt.Sprintf("\033[4%dm", i), fmt.Sprintf("\033[10%dm", i),
+		} {
+			if !strings.Contains(demo, code) {
+				t.Errorf("Want %#v in the demo", code)
+			}
+		}
+	}
+	for _, code := range []string{"\033[48;5;16m", "\033[48;5;231m", "\033[48;5;255m"} {
+		if !strings.Contains(demo, code) {
+			t.Errorf("Want %#v in the demo", code)
+		}
+	}
+}
+
+func TestDemo16(t *testing.T) {
+	WithLevel(Level16, func() {
+		if strings.Contains(Demo(), "\033[48;5;") {
+			t.Errorf("Want no 256 colors at Level16")
+		}
+	})
+}
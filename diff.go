@@ -0,0 +1,71 @@
+package color
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// diffToken matches the words and the runs of whitespace WordDiff compares.
+var diffToken = regexp.MustCompile(`\s+|\S+`)
+
+// WordDiff compares two versions of a line word by word, like
+// `git diff --word-diff`, and gives them back with the words removed from
+// before painted red and the words added in after painted green, the rest
+// being left plain, i.e:
+//
+//	old, new := color.WordDiff("timeout = 30s", "timeout = 45s")
+//	fmt.Println("-", old)
+//	fmt.Println("+", new)
+//
+// The words are found with a longest common subsequence, so it's meant for
+// lines rather than whole files.
+func WordDiff(before, after string) (string, string) {
+	a := diffToken.FindAllString(before, -1)
+	b := diffToken.FindAllString(after, -1)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	red, green := NewStyle("", RedPaint).BrushNonEmpty(), NewStyle("", GreenPaint).BrushNonEmpty()
+	var removed, added, oldBuf, newBuf bytes.Buffer
+	flush := func() {
+		oldBuf.WriteString(red(removed.String()))
+		newBuf.WriteString(green(added.String()))
+		removed.Reset()
+		added.Reset()
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			oldBuf.WriteString(a[i])
+			newBuf.WriteString(b[j])
+			i, j = i+1, j+1
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			removed.WriteString(a[i])
+			i++
+		default:
+			added.WriteString(b[j])
+			j++
+		}
+	}
+	flush()
+	return oldBuf.String(), newBuf.String()
+}
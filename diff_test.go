@@ -0,0 +1,30 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestWordDiff(t *testing.T) {
+	for _, test := range []struct {
+		name, before, after string
+		wantBefore          string
+		wantAfter           string
+	}{
+		{"insertion", "retry the request", "retry the failed request",
+			"retry the request", "retry the " + Green("failed ") + "request"},
+		{"deletion", "close the open file", "close the file",
+			"close the " + Red("open ") + "file", "close the file"},
+		{"substitution", "timeout = 30s", "timeout = 45s",
+			"timeout = " + Red("30s"), "timeout = " + Green("45s")},
+		{"same", "no change", "no change", "no change", "no change"},
+		{"from nothing", "", "new line", "", Green("new line")},
+	} {
+		gotBefore, gotAfter := WordDiff(test.before, test.after)
+		if gotBefore != test.wantBefore {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.wantBefore, gotBefore)
+		}
+		if gotAfter != test.wantAfter {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.wantAfter, gotAfter)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package color
+
+import (
+	"math"
+)
+
+// DistanceMetric is how the distance between two colors is measured when
+// looking for the nearest one, such as when downsampling.
+type DistanceMetric int
+
+// Distance metrics, from the fastest to the most perceptually accurate.
+const (
+	// Euclidean is the straight distance between RGB values.
+	Euclidean DistanceMetric = iota
+	// CIE76 is the straight distance in the CIELAB color space, which is
+	// closer to how colors are perceived.
+	CIE76
+	// CIEDE2000 corrects CIE76 for the hues, saturated and dark colors it
+	// gets wrong.
+	CIEDE2000
+)
+
+// SetDistanceMetric changes the metric used by NearestPaint, Nearest256,
+// NearestNamed and the downsampling of paints, Euclidean by default.
+func SetDistanceMetric(m DistanceMetric) {
+	settings.Lock()
+	settings.metric = m
+	settings.Unlock()
+}
+
+func distanceMetric() DistanceMetric {
+	settings.RLock()
+	m := settings.metric
+	settings.RUnlock()
+	return m
+}
+
+// distance gives how far apart two RGB colors are according to the metric.
+// It can only be compared with other distances of the same metric.
+func (m DistanceMetric) distance(r1, g1, b1, r2, g2, b2 uint8) float64 {
+	switch m {
+	case CIE76:
+		l1, a1, bb1 := lab(r1, g1, b1)
+		l2, a2, bb2 := lab(r2, g2, b2)
+		dl, da, db := l1-l2, a1-a2, bb1-bb2
+		return dl*dl + da*da + db*db
+	case CIEDE2000:
+		l1, a1, bb1 := lab(r1, g1, b1)
+		l2, a2, bb2 := lab(r2, g2, b2)
+		return ciede2000(l1, a1, bb1, l2, a2, bb2)
+	}
+	dr := float64(r1) - float64(r2)
+	dg := float64(g1) - float64(g2)
+	db := float64(b1) - float64(b2)
+	return dr*dr + dg*dg + db*db
+}
+
+// lab converts an sRGB color to CIELAB, with the D65 white point.
+func lab(r, g, b uint8) (l, a, bb float64) {
+	lr, lg, lb := linear(r), linear(g), linear(b)
+	x := (0.4124*lr + 0.3576*lg + 0.1805*lb) / 0.95047
+	y := 0.2126*lr + 0.7152*lg + 0.0722*lb
+	z := (0.0193*lr + 0.1192*lg + 0.9505*lb) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
+
+// ciede2000 gives the CIEDE2000 color difference between two CIELAB
+// colors, following "The CIEDE2000 Color-Difference Formula" by Sharma, Wu
+// and Dalal.
+func ciede2000(l1, a1, b1, l2, a2, b2 float64) float64 {
+	const pow25to7 = 6103515625 // 25^7
+
+	cBar := (math.Hypot(a1, b1) + math.Hypot(a2, b2)) / 2
+	cBar7 := math.Pow(cBar, 7)
+	g := 0.5 * (1 - math.Sqrt(cBar7/(cBar7+pow25to7)))
+
+	a1p, a2p := (1+g)*a1, (1+g)*a2
+	c1p, c2p := math.Hypot(a1p, b1), math.Hypot(a2p, b2)
+	h1p, h2p := hueAngle(b1, a1p), hueAngle(b2, a2p)
+
+	dLp := l2 - l1
+	dCp := c2p - c1p
+	var dhp float64
+	if c1p*c2p != 0 {
+		dhp = h2p - h1p
+		switch {
+		case dhp > 180:
+			dhp -= 360
+		case dhp < -180:
+			dhp += 360
+		}
+	}
+	dHp := 2 * math.Sqrt(c1p*c2p) * math.Sin(radians(dhp/2))
+
+	lBarp := (l1 + l2) / 2
+	cBarp := (c1p + c2p) / 2
+	hBarp := h1p + h2p
+	if c1p*c2p != 0 {
+		switch {
+		case math.Abs(h1p-h2p) <= 180:
+			hBarp /= 2
+		case hBarp < 360:
+			hBarp = (hBarp + 360) / 2
+		default:
+			hBarp = (hBarp - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos(radians(hBarp-30)) +
+		0.24*math.Cos(radians(2*hBarp)) +
+		0.32*math.Cos(radians(3*hBarp+6)) -
+		0.20*math.Cos(radians(4*hBarp-63))
+	dTheta := 30 * math.Exp(-math.Pow((hBarp-275)/25, 2))
+	cBarp7 := math.Pow(cBarp, 7)
+	rc := 2 * math.Sqrt(cBarp7/(cBarp7+pow25to7))
+	sl := 1 + 0.015*math.Pow(lBarp-50, 2)/math.Sqrt(20+math.Pow(lBarp-50, 2))
+	sc := 1 + 0.045*cBarp
+	sh := 1 + 0.015*cBarp*t
+	rt := -math.Sin(radians(2*dTheta)) * rc
+
+	dl, dc, dh := dLp/sl, dCp/sc, dHp/sh
+	return math.Sqrt(dl*dl + dc*dc + dh*dh + rt*dc*dh)
+}
+
+// hueAngle gives the angle of (a, b) in degrees, in [0, 360).
+func hueAngle(b, a float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
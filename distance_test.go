@@ -0,0 +1,62 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+// pairs from the test data of Sharma, Wu and Dalal
+var ciede2000TT = []struct {
+	l1, a1, b1 float64
+	l2, a2, b2 float64
+	want       float64
+}{
+	{50, 2.6772, -79.7751, 50, 0, -82.7485, 2.0425},
+	{50, 0, 0, 50, -1, 2, 2.3669},
+	{50, 2.5, 0, 73, 25, -18, 27.1492},
+	{50, 2.5, 0, 50, 0, -2.5, 4.3065},
+	{60.2574, -34.0099, 36.2677, 60.4626, -34.1751, 39.4387, 1.2644},
+}
+
+func TestCIEDE2000(t *testing.T) {
+	for _, test := range ciede2000TT {
+		got := ciede2000(test.l1, test.a1, test.b1, test.l2, test.a2, test.b2)
+		if math.Abs(got-test.want) > 0.0001 {
+			t.Errorf("%v: Want %v, got %v", test, test.want, got)
+		}
+	}
+}
+
+func TestLab(t *testing.T) {
+	l, a, b := lab(255, 255, 255)
+	if math.Abs(l-100) > 0.01 || math.Abs(a) > 0.02 || math.Abs(b) > 0.02 {
+		t.Errorf("Want white at (100, 0, 0), got (%v, %v, %v)", l, a, b)
+	}
+	if l, _, _ := lab(0, 0, 0); l != 0 {
+		t.Errorf("Want black at L 0, got %v", l)
+	}
+}
+
+func TestDistanceMetric(t *testing.T) {
+	defer SetDistanceMetric(Euclidean)
+
+	// navy is closer to black than to dark blue in RGB, but looks blue
+	if want, got := BlackPaint, NearestPaint(0, 0, 102); got != want {
+		t.Errorf("Euclidean: Want %#v, got %#v", want, got)
+	}
+	SetDistanceMetric(CIEDE2000)
+	if want, got := DarkBluePaint, NearestPaint(0, 0, 102); got != want {
+		t.Errorf("CIEDE2000: Want %#v, got %#v", want, got)
+	}
+	if name, _, _ := NearestNamed("#000066"); name != "darkblue" {
+		t.Errorf("CIEDE2000: Want %#v, got %#v", "darkblue", name)
+	}
+	if want, got := Cube(0, 0, 2), Nearest256(0, 51, 102); got != want {
+		t.Errorf("CIEDE2000: Want %#v, got %#v", want, got)
+	}
+
+	SetDistanceMetric(Euclidean)
+	if want, got := Cube(0, 1, 1), Nearest256(0, 51, 102); got != want {
+		t.Errorf("Euclidean: Want %#v, got %#v", want, got)
+	}
+}
@@ -5,6 +5,11 @@
 //
 //		fmt.Printf("This is %s\n", brush.Red("red"))
 //
+// The same brushes live in this package too, where they can be chained with
+// text attributes :
+//
+//		fmt.Printf("This is %s\n", color.Red.Bold().Underline()("bold and underlined"))
+//
 // ...or you can create new ones!
 //
 //		weird := color.NewBrush(color.PurplePaint, color.CyanPaint)
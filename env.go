@@ -0,0 +1,219 @@
+package color
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// TerminalBackgroundIsDark tells if the terminal has a dark background,
+// according to the COLORFGBG environment variable some terminals export,
+// such as `15;0` for white text on a black background.  ok is false if the
+// variable is missing or can't be understood.
+func TerminalBackgroundIsDark() (dark bool, ok bool) {
+	return backgroundIsDark(os.Getenv("COLORFGBG"))
+}
+
+func backgroundIsDark(colorfgbg string) (dark bool, ok bool) {
+	if colorfgbg == "" {
+		return false, false
+	}
+
+	// the background is the last field, there can be a middle one
+	fields := strings.Split(colorfgbg, ";")
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if len(fields) < 2 || err != nil || bg < 0 || bg >= len(palette) {
+		return false, false
+	}
+
+	l, _ := Luminance(palette[bg].p)
+	return l < 0.5, true
+}
+
+// ColorsFromEnv tells if the environment wants colors, according to the
+// variables many tools honor.  The first that applies wins:
+//
+//   - colors were turned off with Disable: no colors
+//   - NO_COLOR is set to anything but an empty string: no colors
+//   - CLICOLOR_FORCE is set and isn't `0`: colors, forced
+//   - CLICOLOR is `0`: no colors
+//
+// Otherwise colors are wanted, if the output is a terminal.  forced tells
+// that colors are wanted even if it isn't.
+func ColorsFromEnv() (enabled, forced bool) {
+	if atomic.LoadInt32(&disabled) != 0 {
+		return false, false
+	}
+	return envColors()
+}
+
+// envColors is ColorsFromEnv, ignoring Disable.
+func envColors() (enabled, forced bool) {
+	switch {
+	case os.Getenv("NO_COLOR") != "":
+		return false, false
+	case os.Getenv("CLICOLOR_FORCE") != "" && os.Getenv("CLICOLOR_FORCE") != "0":
+		return true, true
+	case os.Getenv("CLICOLOR") == "0":
+		return false, false
+	}
+	return true, false
+}
+
+// AutoDetect enables or disables colors for output going to f, usually
+// os.Stdout, i.e:
+//
+//	func main() {
+//		color.AutoDetect(os.Stdout)
+//		fmt.Println(color.Green("ok"))
+//	}
+//
+// Colors are enabled if f is a terminal other than TERM=dumb, or if
+// CLICOLOR_FORCE forces them, unless NO_COLOR or CLICOLOR=0 turn them off,
+// as in ColorsFromEnv.  They are disabled otherwise, such as when the output
+// is piped to a file or to less, and the brushes then give back their text
+// unchanged.
+func AutoDetect(f *os.File) {
+	enabled, forced := envColors()
+	if forced || enabled && f != nil && isTerminal(f) && os.Getenv("TERM") != "dumb" {
+		Enable()
+	} else {
+		Disable()
+	}
+}
+
+// trueColor overrides the detection of SupportsTrueColor when it's not
+// trueColorDetect.
+var trueColor int32
+
+const (
+	trueColorDetect int32 = iota
+	trueColorYes
+	trueColorNo
+)
+
+// trueColorPrograms are values of TERM_PROGRAM for terminals known to
+// support 24-bit colors without saying so in COLORTERM.
+var trueColorPrograms = []string{"iTerm.app", "WezTerm", "vscode", "Hyper"}
+
+// trueColorTerms are values of TERM for terminals known to support 24-bit
+// colors.
+var trueColorTerms = []string{"xterm-kitty", "alacritty", "foot", "xterm-ghostty", "contour"}
+
+// SupportsTrueColor tells if the terminal can display 24-bit colors, made
+// with PaintRGB, according to COLORTERM being `truecolor` or `24bit` and to
+// a list of terminals known to support them.  Use OverrideTrueColor to
+// decide for it, in tests for example.
+func SupportsTrueColor() bool {
+	switch atomic.LoadInt32(&trueColor) {
+	case trueColorYes:
+		return true
+	case trueColorNo:
+		return false
+	}
+
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" {
+		return true // Windows Terminal
+	}
+
+	term := os.Getenv("TERM")
+	if strings.HasSuffix(term, "-truecolor") || strings.HasSuffix(term, "-direct") {
+		return true
+	}
+	return contains(trueColorTerms, term) || contains(trueColorPrograms, os.Getenv("TERM_PROGRAM"))
+}
+
+// OverrideTrueColor makes SupportsTrueColor answer supported, whatever the
+// environment says.
+func OverrideTrueColor(supported bool) {
+	v := trueColorNo
+	if supported {
+		v = trueColorYes
+	}
+	atomic.StoreInt32(&trueColor, v)
+}
+
+// DetectTrueColor undoes OverrideTrueColor, SupportsTrueColor looks at the
+// environment again.
+func DetectTrueColor() {
+	atomic.StoreInt32(&trueColor, trueColorDetect)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveColorMode settles whether to paint and at which level, the way
+// most CLIs do, from the value of a `--color` flag and the environment.
+// The first that applies wins:
+//
+//   - the flag is `never`: no colors
+//   - the flag is `always`: colors
+//   - ColorsFromEnv wants no colors, or forces them
+//   - the standard output is a terminal whose TERM isn't `dumb`: colors
+//
+// The flag being `auto`, empty or anything else leaves it to the
+// environment.  The level is LevelNone without colors, and otherwise the
+// one TERM and COLORTERM tell, i.e:
+//
+//	enabled, level := color.ResolveColorMode(*colorFlag)
+//	if !enabled {
+//		color.Disable()
+//	}
+//	color.SetColorLevel(level)
+func ResolveColorMode(flag string) (enabled bool, level Level) {
+	return resolveColorMode(flag, isTerminal(os.Stdout))
+}
+
+func resolveColorMode(flag string, tty bool) (enabled bool, level Level) {
+	switch strings.ToLower(flag) {
+	case "never":
+		return false, LevelNone
+	case "always":
+		return true, DetectColorLevel()
+	}
+
+	enabled, forced := ColorsFromEnv()
+	switch {
+	case forced:
+		return true, DetectColorLevel()
+	case !enabled || !tty || os.Getenv("TERM") == "dumb":
+		return false, LevelNone
+	}
+	return true, DetectColorLevel()
+}
+
+// DetectColorLevel gives the color level of the terminal according to
+// COLORTERM and TERM, assuming it has colors: LevelTrueColor if
+// SupportsTrueColor, Level256 for a TERM such as `xterm-256color` and
+// Level16 otherwise, i.e:
+//
+//	color.SetColorLevel(color.DetectColorLevel())
+//
+// The paints beyond the level are then downsampled to the nearest colors it
+// has.  Use ResolveColorMode to also know if there should be colors at all.
+func DetectColorLevel() Level {
+	switch term := os.Getenv("TERM"); {
+	case SupportsTrueColor():
+		return LevelTrueColor
+	case strings.Contains(term, "256color"):
+		return Level256
+	}
+	return Level16
+}
+
+// isTerminal tells if f is a character device, as terminals are.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,264 @@
+package color
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// setenv sets an environment variable for the duration of a test, give
+// the returned function to defer to restore it.
+func setenv(key, value string) func() {
+	old, had := os.LookupEnv(key)
+	if value == "" {
+		os.Unsetenv(key)
+	} else {
+		os.Setenv(key, value)
+	}
+	return func() {
+		if had {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+var colorfgbgTT = []struct {
+	value    string
+	dark, ok bool
+}{
+	{"15;0", true, true},
+	{"0;15", false, true},
+	{"15;default;0", true, true},
+	{"0;7", false, true},
+	{"7;8", true, true},
+	{"", false, false},
+	{"15", false, false},
+	{"15;blue", false, false},
+	{"15;16", false, false},
+}
+
+func TestTerminalBackgroundIsDark(t *testing.T) {
+	for _, test := range colorfgbgTT {
+		restore := setenv("COLORFGBG", test.value)
+		dark, ok := TerminalBackgroundIsDark()
+		restore()
+
+		if dark != test.dark || ok != test.ok {
+			t.Errorf("COLORFGBG=%q: want (%v, %v), got (%v, %v)", test.value, test.dark, test.ok, dark, ok)
+		}
+	}
+}
+
+var trueColorTT = []struct {
+	colorterm, term, program string
+	want                     bool
+}{
+	{"truecolor", "xterm-256color", "", true},
+	{"24bit", "xterm-256color", "", true},
+	{"TrueColor", "", "", true},
+	{"", "xterm-256color", "", false},
+	{"", "", "", false},
+	{"", "xterm-kitty", "", true},
+	{"", "xterm-direct", "", true},
+	{"", "xterm-256color", "iTerm.app", true},
+	{"", "xterm-256color", "Apple_Terminal", false},
+}
+
+func TestSupportsTrueColor(t *testing.T) {
+	defer setenv("WT_SESSION", "")()
+	for _, test := range trueColorTT {
+		restoreColorterm := setenv("COLORTERM", test.colorterm)
+		restoreTerm := setenv("TERM", test.term)
+		restoreProgram := setenv("TERM_PROGRAM", test.program)
+		got := SupportsTrueColor()
+		restoreProgram()
+		restoreTerm()
+		restoreColorterm()
+
+		if got != test.want {
+			t.Errorf("COLORTERM=%q TERM=%q TERM_PROGRAM=%q: want %v, got %v",
+				test.colorterm, test.term, test.program, test.want, got)
+		}
+	}
+}
+
+func TestOverrideTrueColor(t *testing.T) {
+	defer setenv("COLORTERM", "truecolor")()
+	defer DetectTrueColor()
+
+	OverrideTrueColor(false)
+	if SupportsTrueColor() {
+		t.Errorf("want no truecolor when overridden")
+	}
+	OverrideTrueColor(true)
+	if !SupportsTrueColor() {
+		t.Errorf("want truecolor when overridden")
+	}
+	DetectTrueColor()
+	if !SupportsTrueColor() {
+		t.Errorf("want truecolor from COLORTERM once detected again")
+	}
+}
+
+var colorsFromEnvTT = []struct {
+	noColor, force, clicolor string
+	enabled, forced          bool
+}{
+	{"", "", "", true, false},
+	{"1", "", "", false, false},
+	{"", "1", "", true, true},
+	{"", "0", "", true, false},
+	{"", "", "0", false, false},
+	{"", "", "1", true, false},
+	{"1", "1", "", false, false},
+	{"", "1", "0", true, true},
+	{"1", "", "1", false, false},
+}
+
+func TestColorsFromEnv(t *testing.T) {
+	for _, test := range colorsFromEnvTT {
+		restoreNoColor := setenv("NO_COLOR", test.noColor)
+		restoreForce := setenv("CLICOLOR_FORCE", test.force)
+		restoreCLIColor := setenv("CLICOLOR", test.clicolor)
+		enabled, forced := ColorsFromEnv()
+		restoreCLIColor()
+		restoreForce()
+		restoreNoColor()
+
+		if enabled != test.enabled || forced != test.forced {
+			t.Errorf("NO_COLOR=%q CLICOLOR_FORCE=%q CLICOLOR=%q: want (%v, %v), got (%v, %v)",
+				test.noColor, test.force, test.clicolor, test.enabled, test.forced, enabled, forced)
+		}
+	}
+}
+
+func TestColorsFromEnvDisabled(t *testing.T) {
+	defer setenv("CLICOLOR_FORCE", "1")()
+	Disable()
+	defer Enable()
+
+	if enabled, forced := ColorsFromEnv(); enabled || forced {
+		t.Errorf("Want Disable to win over CLICOLOR_FORCE, got (%v, %v)", enabled, forced)
+	}
+}
+
+var resolveColorModeTT = []struct {
+	flag    string
+	noColor string
+	tty     bool
+	enabled bool
+}{
+	{"always", "", false, true},
+	{"always", "1", false, true},
+	{"always", "1", true, true},
+	{"never", "", true, false},
+	{"never", "", false, false},
+	{"auto", "", true, true},
+	{"auto", "", false, false},
+	{"auto", "1", true, false},
+	{"", "", true, true},
+	{"AUTO", "1", false, false},
+}
+
+func TestResolveColorMode(t *testing.T) {
+	defer setenv("CLICOLOR_FORCE", "")()
+	defer setenv("CLICOLOR", "")()
+	defer setenv("COLORTERM", "")()
+	defer setenv("TERM_PROGRAM", "")()
+	defer setenv("WT_SESSION", "")()
+	defer setenv("TERM", "xterm-256color")()
+
+	for _, test := range resolveColorModeTT {
+		restore := setenv("NO_COLOR", test.noColor)
+		enabled, level := resolveColorMode(test.flag, test.tty)
+		restore()
+
+		wantLevel := LevelNone
+		if test.enabled {
+			wantLevel = Level256
+		}
+		if enabled != test.enabled || level != wantLevel {
+			t.Errorf("--color=%s NO_COLOR=%q tty=%v: want (%v, %d), got (%v, %d)",
+				test.flag, test.noColor, test.tty, test.enabled, wantLevel, enabled, level)
+		}
+	}
+}
+
+func TestResolveColorModeEnv(t *testing.T) {
+	defer setenv("NO_COLOR", "")()
+	defer setenv("CLICOLOR", "")()
+	defer setenv("COLORTERM", "truecolor")()
+	defer setenv("TERM", "dumb")()
+
+	// a dumb terminal gets no colors, unless they're forced
+	if enabled, _ := resolveColorMode("auto", true); enabled {
+		t.Errorf("Want no colors on a dumb terminal")
+	}
+	defer setenv("CLICOLOR_FORCE", "1")()
+	if enabled, level := resolveColorMode("auto", false); !enabled || level != LevelTrueColor {
+		t.Errorf("Want forced truecolor, got (%v, %d)", enabled, level)
+	}
+}
+
+func TestAutoDetect(t *testing.T) {
+	defer Enable()
+	defer setenv("NO_COLOR", "")()
+	defer setenv("CLICOLOR", "")()
+	defer setenv("CLICOLOR_FORCE", "")()
+
+	f, err := ioutil.TempFile("", "autodetect")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	// a file isn't a terminal
+	AutoDetect(f)
+	if got := Red("x"); got != "x" {
+		t.Errorf("Want %#v, got %#v", "x", got)
+	}
+
+	setenv("CLICOLOR_FORCE", "1")
+	AutoDetect(f)
+	if want, got := "\033[1;31m"+"x"+"\033[0m", Red("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	setenv("NO_COLOR", "1")
+	AutoDetect(f)
+	if got := Red("x"); got != "x" {
+		t.Errorf("Want %#v, got %#v", "x", got)
+	}
+}
+
+var colorLevelTT = []struct {
+	colorterm, term string
+	want            Level
+}{
+	{"truecolor", "xterm-256color", LevelTrueColor},
+	{"24bit", "xterm", LevelTrueColor},
+	{"", "xterm-kitty", LevelTrueColor},
+	{"", "xterm-256color", Level256},
+	{"", "screen-256color", Level256},
+	{"", "xterm", Level16},
+	{"", "", Level16},
+}
+
+func TestDetectColorLevel(t *testing.T) {
+	defer setenv("WT_SESSION", "")()
+	defer setenv("TERM_PROGRAM", "")()
+	for _, test := range colorLevelTT {
+		restoreColorterm := setenv("COLORTERM", test.colorterm)
+		restoreTerm := setenv("TERM", test.term)
+		got := DetectColorLevel()
+		restoreTerm()
+		restoreColorterm()
+
+		if got != test.want {
+			t.Errorf("COLORTERM=%q TERM=%q: want %d, got %d", test.colorterm, test.term, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package color
+
+import (
+	"regexp"
+	"strings"
+)
+
+// EnvOptions are the brushes used to paint environment variables, and how
+// to tell and hide the secret ones.  A nil Brush leaves its part plain.
+type EnvOptions struct {
+	Key   Brush
+	Value Brush
+	// Sensitive matches the keys whose values are secrets, they are
+	// painted with SensitiveValue instead of Value.  A nil Sensitive
+	// treats no key as sensitive.
+	Sensitive      *regexp.Regexp
+	SensitiveValue Brush
+	// Mask replaces the values of sensitive keys when it isn't empty.
+	Mask string
+}
+
+// DefaultEnvOptions paints keys in cyan and values in light gray, like
+// DefaultKeyValueOptions, and masks the values of keys mentioning a secret,
+// a token, a password or a key, in red.  It is used by HighlightEnv.
+var DefaultEnvOptions = EnvOptions{
+	Key:            Cyan,
+	Value:          LightGray,
+	Sensitive:      regexp.MustCompile(`(?i)SECRET|TOKEN|PASSW(OR)?D|API_?KEY|PRIVATE_?KEY|CREDENTIAL`),
+	SensitiveValue: Red,
+	Mask:           "********",
+}
+
+// envLine matches a `KEY=value` line, optionally exported as in shell
+// scripts.
+var envLine = regexp.MustCompile(`^(\s*(?:export\s+)?)([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+
+// HighlightEnv paints the `KEY=value` lines of s, such as the output of
+// `env` or a .env file, according to DefaultEnvOptions, i.e:
+//
+//	fmt.Println(color.HighlightEnv(strings.Join(os.Environ(), "\n")))
+func HighlightEnv(s string) string {
+	return DefaultEnvOptions.HighlightEnv(s)
+}
+
+// HighlightEnv paints the keys and values of the `KEY=value` lines of s,
+// masking the values of sensitive keys.  The `=`, empty values and the
+// other lines, such as comments, are left plain.
+func (o EnvOptions) HighlightEnv(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		m := envLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		prefix, key, value := m[1], m[2], m[3]
+
+		valueBrush := o.Value
+		if o.Sensitive != nil && o.Sensitive.MatchString(key) {
+			valueBrush = o.SensitiveValue
+			if o.Mask != "" && value != "" {
+				value = o.Mask
+			}
+		}
+		if value != "" {
+			value = valueBrush.paint(value)
+		}
+		lines[i] = prefix + o.Key.paint(key) + "=" + value
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,32 @@
+package color
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestHighlightEnv(t *testing.T) {
+	in := "HOME=/home/dev\nGITHUB_TOKEN=ghp_123\n# comment\nexport db_password=hunter2\nEMPTY_SECRET=\nnot a variable"
+	want := Cyan("HOME") + "=" + LightGray("/home/dev") + "\n" +
+		Cyan("GITHUB_TOKEN") + "=" + Red("********") + "\n" +
+		"# comment\n" +
+		"export " + Cyan("db_password") + "=" + Red("********") + "\n" +
+		Cyan("EMPTY_SECRET") + "=\n" +
+		"not a variable"
+	if got := HighlightEnv(in); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestHighlightEnvOptions(t *testing.T) {
+	opts := EnvOptions{
+		Key:            Blue,
+		Sensitive:      regexp.MustCompile(`^INTERNAL_`),
+		SensitiveValue: Yellow,
+	}
+
+	want := Blue("INTERNAL_URL") + "=" + Yellow("http://10.0.0.1") + "\n" + Blue("API_TOKEN") + "=abc"
+	if got := opts.HighlightEnv("INTERNAL_URL=http://10.0.0.1\nAPI_TOKEN=abc"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
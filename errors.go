@@ -0,0 +1,26 @@
+package color
+
+import (
+	"errors"
+	"fmt"
+)
+
+type prefixedError struct {
+	err error
+}
+
+// Errorf formats an error like fmt.Errorf, with an `error:` prefix painted
+// in red.  The prefix is painted when Error is called, so that the message
+// is plain if colors are disabled by then.
+func Errorf(format string, a ...interface{}) error {
+	return &prefixedError{err: fmt.Errorf(format, a...)}
+}
+
+func (e *prefixedError) Error() string {
+	return Red("error:") + " " + e.err.Error()
+}
+
+// Unwrap gives the error wrapped with %w, if any.
+func (e *prefixedError) Unwrap() error {
+	return errors.Unwrap(e.err)
+}
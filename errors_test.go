@@ -0,0 +1,31 @@
+package color
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestErrorf(t *testing.T) {
+	err := Errorf("can't open %q", "file")
+
+	want := "\033[1;31m" + "error:" + "\033[0m" + ` can't open "file"`
+	if got := err.Error(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	Disable()
+	defer Enable()
+
+	want = `error: can't open "file"`
+	if got := err.Error(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestErrorfWraps(t *testing.T) {
+	err := Errorf("reading: %w", io.EOF)
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Want %v to wrap io.EOF", err)
+	}
+}
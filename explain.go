@@ -0,0 +1,180 @@
+package color
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ansiNames are the names of the 8 ANSI colors, by their number.
+var ansiNames = [8]string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+var sgrOffNames = map[int]string{
+	0:  "reset",
+	22: "normal intensity",
+	23: "not italic",
+	24: "not underlined",
+	25: "not blinking",
+	27: "not reversed",
+	28: "not concealed",
+	29: "not crossed out",
+	39: "default foreground",
+	49: "default background",
+}
+
+// Explain describes, in order, what each of the color and attribute
+// sequences of s does, i.e:
+//
+//	color.Explain(color.Red("x")) // "bold", "foreground red", "reset"
+//
+// Sequences setting many things at once give one description per thing.
+func Explain(s string) []string {
+	var explained []string
+	eachToken(s, func(tok string, escape bool) {
+		if !escape {
+			return
+		}
+		params, ok := sgrParams([]byte(tok))
+		if !ok {
+			return
+		}
+		for _, param := range splitSGR(params) {
+			explained = append(explained, explainSGR(param))
+		}
+	})
+	return explained
+}
+
+func explainSGR(param sgrParam) string {
+	switch n := param.n; {
+	case n == 4 && param.sub == "0":
+		return "not underlined"
+	case n == 4 && param.sub != "":
+		return Attribute("4:" + param.sub).String()
+	case n >= 1 && n <= 9:
+		return Attribute(strconv.Itoa(n)).String()
+	case n >= 30 && n <= 37:
+		return "foreground " + ansiNames[n-30]
+	case n >= 40 && n <= 47:
+		return "background " + ansiNames[n-40]
+	case n >= 90 && n <= 97:
+		return "foreground bright " + ansiNames[n-90]
+	case n >= 100 && n <= 107:
+		return "background bright " + ansiNames[n-100]
+	case (n == 38 || n == 48) && param.paint != "":
+		channel := "foreground "
+		if n == 48 {
+			channel = "background "
+		}
+		return channel + explainPaint(param.paint)
+	}
+	if name, ok := sgrOffNames[param.n]; ok {
+		return name
+	}
+	return "unknown " + param.raw
+}
+
+func explainPaint(p Paint) string {
+	if i, ok := p.index256(); ok {
+		return "color " + strconv.Itoa(int(i))
+	}
+	r, g, b, _ := p.RGB()
+	return fmt.Sprintf("rgb(%d,%d,%d)", r, g, b)
+}
+
+// Reveal replaces the sequences of s with readable tokens, so that styling
+// shows in test failures and golden files, i.e:
+//
+//	color.Reveal(color.Red("hi")) // "{red}hi{reset}"
+//
+// The 16 colors use the names ParsePaint knows, backgrounds are prefixed
+// with `bg:`, and other parameters are named as Explain does.  The leading
+// `0;` of the dark paints and the `1;` of the bright ones are folded into
+// their color.  Escape sequences other than colors are quoted.
+func Reveal(s string) string {
+	var buf bytes.Buffer
+	eachToken(s, func(tok string, escape bool) {
+		if !escape {
+			buf.WriteString(tok)
+			return
+		}
+		params, ok := sgrParams([]byte(tok))
+		if !ok {
+			fmt.Fprintf(&buf, "{%q}", tok)
+			return
+		}
+		for _, name := range revealSGR(splitSGR(params)) {
+			buf.WriteString("{" + name + "}")
+		}
+	})
+	return buf.String()
+}
+
+func revealSGR(params []sgrParam) []string {
+	if len(params) > 1 && params[0].n == 0 && params[1].n >= 30 && params[1].n <= 37 {
+		params = params[1:] // the prefix of the dark paints
+	}
+
+	var names []string
+	for i := 0; i < len(params); i++ {
+		switch n := params[i].n; {
+		case n == 1 && i+1 < len(params) && params[i+1].n >= 30 && params[i+1].n <= 37:
+			names = append(names, palette[params[i+1].n-30+8].name)
+			i++
+		case n >= 30 && n <= 37:
+			names = append(names, palette[n-30].name)
+		case n >= 40 && n <= 47:
+			names = append(names, "bg:"+palette[n-40].name)
+		case n >= 90 && n <= 97:
+			names = append(names, palette[n-90+8].name)
+		case n >= 100 && n <= 107:
+			names = append(names, "bg:"+palette[n-100+8].name)
+		case n == 38 && params[i].paint != "":
+			names = append(names, explainPaint(params[i].paint))
+		case n == 48 && params[i].paint != "":
+			names = append(names, "bg:"+explainPaint(params[i].paint))
+		default:
+			names = append(names, explainSGR(params[i]))
+		}
+	}
+	return names
+}
+
+// HasStyle tells if some of s is painted with the style, that is if s has
+// the style's code followed by a reset, with anything around or between
+// them.  It's meant for tests, i.e:
+//
+//	if !color.HasStyle(out, errStyle) {
+//		t.Errorf("want the error painted, got %q", out)
+//	}
+//
+// It is false for styles painting nothing.
+func HasStyle(s string, style Style) bool {
+	if style.code == "" {
+		return false
+	}
+	i := strings.Index(s, style.code)
+	return i >= 0 && strings.Contains(s[i+len(style.code):], style.reset())
+}
+
+// IsBalanced tells if s leaves the terminal as it found it, with every
+// color and attribute it sets turned off by the end of the string, by a
+// reset or by the matching off codes.  It catches composed output leaving
+// its last style open, i.e:
+//
+//	if !color.IsBalanced(out) {
+//		t.Errorf("%q leaves colors on", out)
+//	}
+func IsBalanced(s string) bool {
+	var style Style
+	eachToken(s, func(tok string, escape bool) {
+		if !escape {
+			return
+		}
+		if params, ok := sgrParams([]byte(tok)); ok {
+			style = applySGR(style, params)
+		}
+	})
+	return style == Style{}
+}
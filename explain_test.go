@@ -0,0 +1,109 @@
+package color
+
+import (
+	"reflect"
+	"testing"
+)
+
+var explainTT = []struct {
+	s    string
+	want []string
+}{
+	{"plain", nil},
+	{Red("x"), []string{"bold", "foreground red", "reset"}},
+	{NewBrush(DarkBluePaint, YellowPaint)("x"), []string{"bold", "foreground yellow", "background blue", "reset"}},
+	{"\033[48;2;255;0;0m\033[38;5;200mx", []string{"background rgb(255,0,0)", "foreground color 200"}},
+	{"\033[4;9;92;103m", []string{"underline", "strikethrough", "foreground bright green", "background bright yellow"}},
+	{"\033[22;39;49;53m", []string{"normal intensity", "default foreground", "default background", "unknown 53"}},
+	{"\033[m\033[2J", []string{"reset"}},
+}
+
+func TestExplain(t *testing.T) {
+	for _, test := range explainTT {
+		if got := Explain(test.s); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%#v: Want %#v, got %#v", test.s, test.want, got)
+		}
+	}
+}
+
+var revealTT = []struct {
+	s, want string
+}{
+	{Red("hi"), "{red}hi{reset}"},
+	{DarkRed("hi"), "{darkred}hi{reset}"},
+	{NewBrush(DarkRedPaint, BluePaint)("hi"), "{blue}{bg:darkred}hi{reset}"},
+	{Green.Bold()("ok"), "{green}{bold}ok{reset}"},
+	{NewStyle("", DarkCyanPaint).WithAttributes(Italic, Underline).Brush()("x"),
+		"{darkcyan}{italic}{underline}x{reset}"},
+	{"\033[91;104mx\033[39;49m", "{red}{bg:blue}x{default foreground}{default background}"},
+	{NewStyle(Gray(3), PaintRGB(1, 2, 3)).Brush()("x"), "{rgb(1,2,3)}{bg:color 235}x{reset}"},
+	{"\033[0;4mx", "{reset}{underline}x"},
+	{"\033[0;41mx", "{reset}{bg:darkred}x"},
+	{"a\033[2Kb", `a{"\x1b[2K"}b`},
+	{"plain", "plain"},
+}
+
+func TestReveal(t *testing.T) {
+	for _, test := range revealTT {
+		if got := Reveal(test.s); got != test.want {
+			t.Errorf("%q: Want %#v, got %#v", test.s, test.want, got)
+		}
+	}
+}
+
+func TestHasStyle(t *testing.T) {
+	style, other := NewStyle("", RedPaint), NewStyle("", DarkRedPaint)
+
+	for _, s := range []string{
+		style.Brush()("x"),
+		"error: " + style.Brush()("x") + " at line 3",
+		style.Brush()("outer " + Blue("inner") + " outer"),
+	} {
+		if !HasStyle(s, style) {
+			t.Errorf("%q: want the style found", s)
+		}
+	}
+
+	for _, s := range []string{
+		other.Brush()("x"),
+		"x",
+		style.code + "x",
+		ResetCode() + style.code,
+	} {
+		if HasStyle(s, style) {
+			t.Errorf("%q: want the style not found", s)
+		}
+	}
+
+	if HasStyle("x", Style{}) {
+		t.Errorf("want no style found for the zero Style")
+	}
+}
+
+func TestIsBalanced(t *testing.T) {
+	underlined := NewStyle("", RedPaint).WithAttributes(Underline)
+	for _, s := range []string{
+		"",
+		"plain",
+		Red("x"),
+		Red("a") + " " + NewBrush(BluePaint, WhitePaint)("b"),
+		Red("outer " + Blue("inner") + " outer"),
+		"\033[1;31mx\033[22;39m",
+		underlined.code + "x" + underlined.Off(),
+	} {
+		if !IsBalanced(s) {
+			t.Errorf("%q: want balanced", s)
+		}
+	}
+
+	for _, s := range []string{
+		NewStyle("", RedPaint).code + "x",
+		Red("a") + "\033[4mb",
+		"\033[1;31mx\033[39m",
+		"\033[44mx\033[0m\033[32m",
+	} {
+		if IsBalanced(s) {
+			t.Errorf("%q: want a dangling style", s)
+		}
+	}
+}
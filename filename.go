@@ -0,0 +1,82 @@
+package color
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// FileCategories gives the theme role of files by their extension, as
+// used by ColorizeFilename.  It can be changed to add extensions or to move
+// them to other roles.
+var FileCategories = map[string]string{
+	// archives
+	".tar": "archive", ".gz": "archive", ".tgz": "archive", ".bz2": "archive",
+	".xz": "archive", ".zst": "archive", ".zip": "archive", ".7z": "archive",
+	".rar": "archive", ".deb": "archive", ".rpm": "archive", ".jar": "archive",
+	// images
+	".png": "image", ".jpg": "image", ".jpeg": "image", ".gif": "image",
+	".bmp": "image", ".svg": "image", ".webp": "image", ".ico": "image",
+	".tif": "image", ".tiff": "image",
+	// audio
+	".mp3": "audio", ".flac": "audio", ".ogg": "audio", ".wav": "audio",
+	".m4a": "audio", ".opus": "audio",
+	// video
+	".mp4": "video", ".mkv": "video", ".webm": "video", ".avi": "video",
+	".mov": "video",
+}
+
+// ColorizeFilename paints a file name by its kind, according to the roles
+// "directory", "executable", "symlink", "archive", "image", "audio" and
+// "video" of DefaultTheme, i.e:
+//
+//	fmt.Println(color.ColorizeFilename("release.tar.gz"))
+func ColorizeFilename(name string) string {
+	return DefaultTheme.ColorizeFilename(name)
+}
+
+// ColorizeFilename paints a file name with the role of its kind.  The kind
+// is told by the markers of `ls -F`, a trailing `/` for directories, `*`
+// for executables and `@` for symbolic links, or else by looking up the
+// extension in FileCategories.  Other files are left plain.
+func (t Theme) ColorizeFilename(name string) string {
+	return t.paint(fileRole(name), name)
+}
+
+func fileRole(name string) string {
+	switch {
+	case strings.HasSuffix(name, "/"):
+		return "directory"
+	case strings.HasSuffix(name, "*"):
+		return "executable"
+	case strings.HasSuffix(name, "@"):
+		return "symlink"
+	}
+	return FileCategories[strings.ToLower(path.Ext(name))]
+}
+
+// ParseLSColors reads the styles of LS_COLORS, in the format of dircolors,
+// such as `di=01;34:ln=36:*.tar=01;31`.  The styles are keyed by the names
+// of the entries, i.e. "di" or "*.tar".  Entries set to `target`, meaning
+// links take the style of what they point to, are skipped.
+func ParseLSColors(env string) (map[string]Style, error) {
+	styles := make(map[string]Style)
+	for _, entry := range strings.Split(env, ":") {
+		if entry == "" {
+			continue
+		}
+		i := strings.IndexByte(entry, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("color: invalid LS_COLORS entry %q, want key=value", entry)
+		}
+		key, value := entry[:i], entry[i+1:]
+		if value == "target" {
+			continue
+		}
+		if strings.Trim(value, "0123456789;") != "" {
+			return nil, fmt.Errorf("color: invalid LS_COLORS entry %q, want SGR parameters", entry)
+		}
+		styles[key] = applySGR(Style{}, value)
+	}
+	return styles, nil
+}
@@ -0,0 +1,70 @@
+package color
+
+import (
+	"reflect"
+	"testing"
+)
+
+var colorizeFilenameTT = []struct {
+	name, role string
+}{
+	{"src/", "directory"},
+	{"build.sh*", "executable"},
+	{"latest@", "symlink"},
+	{"release.tar.gz", "archive"},
+	{"backup.ZIP", "archive"},
+	{"logo.png", "image"},
+	{"song.flac", "audio"},
+	{"talk.mkv", "video"},
+	{"main.go", ""},
+	{"Makefile", ""},
+}
+
+func TestColorizeFilename(t *testing.T) {
+	for _, test := range colorizeFilenameTT {
+		want := test.name
+		if test.role != "" {
+			want = DefaultTheme[test.role].Brush()(test.name)
+		}
+		if got := ColorizeFilename(test.name); got != want {
+			t.Errorf("%q: Want %#v, got %#v", test.name, want, got)
+		}
+	}
+}
+
+func TestFileCategories(t *testing.T) {
+	FileCategories[".go"] = "source"
+	defer delete(FileCategories, ".go")
+
+	theme := Theme{"source": NewStyle("", YellowPaint)}
+	if want, got := Yellow("main.go"), theme.ColorizeFilename("main.go"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestParseLSColors(t *testing.T) {
+	styles, err := ParseLSColors("rs=0:di=01;34:ln=target:ex=01;32:*.tar=01;31:*.png=38;5;200:or=40;31;01:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]Style{
+		"rs":    {},
+		"di":    NewStyle("", "34").WithAttributes(Bold),
+		"ex":    NewStyle("", "32").WithAttributes(Bold),
+		"*.tar": NewStyle("", "31").WithAttributes(Bold),
+		"*.png": NewStyle("", Index(200)),
+		"or":    NewStyle("30", "31").WithAttributes(Bold),
+	}
+	if !reflect.DeepEqual(styles, want) {
+		t.Errorf("Want %#v, got %#v", want, styles)
+	}
+}
+
+func TestParseLSColorsInvalid(t *testing.T) {
+	for _, env := range []string{"di", "=34", "di=blue", "di=01;34:ex=green"} {
+		if _, err := ParseLSColors(env); err == nil {
+			t.Errorf("%q: want an error", env)
+		}
+	}
+}
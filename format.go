@@ -0,0 +1,292 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// paint applies b to text, leaving the text plain if b is nil.
+func (b Brush) paint(text string) string {
+	if b == nil {
+		return text
+	}
+	return b(text)
+}
+
+// SignOptions are the brushes used to paint numbers by their sign.  A nil
+// Brush leaves the number plain.
+type SignOptions struct {
+	Positive Brush
+	Negative Brush
+	Zero     Brush
+}
+
+// DefaultSignOptions paints positive numbers green, negative numbers red
+// and leaves zero plain.  It is used by Signed and SignedInt.
+var DefaultSignOptions = SignOptions{
+	Positive: Green,
+	Negative: Red,
+}
+
+// Signed formats n with a leading sign and paints it according to
+// DefaultSignOptions, i.e:
+//
+//	fmt.Println(color.Signed(1.5))  // a green "+1.5"
+//	fmt.Println(color.Signed(-2))   // a red "-2"
+func Signed(n float64) string {
+	return DefaultSignOptions.Signed(n)
+}
+
+// SignedInt is like Signed, for integers.
+func SignedInt(n int) string {
+	return DefaultSignOptions.SignedInt(n)
+}
+
+// Signed formats n with a leading sign and paints it according to its
+// sign.  Zero has no sign, and NaN, having none either, is left plain.
+func (o SignOptions) Signed(n float64) string {
+	switch {
+	case math.IsNaN(n):
+		return "NaN"
+	case math.IsInf(n, 1):
+		// FormatFloat already gives the sign of +Inf
+		return o.paint(n, "Inf")
+	}
+	return o.paint(n, strconv.FormatFloat(n, 'f', -1, 64))
+}
+
+// SignedInt is like Signed, for integers.
+func (o SignOptions) SignedInt(n int) string {
+	return o.paint(float64(n), strconv.Itoa(n))
+}
+
+func (o SignOptions) paint(n float64, text string) string {
+	switch {
+	case n > 0:
+		return o.Positive.paint("+" + text)
+	case n < 0:
+		return o.Negative.paint(text)
+	default:
+		return o.Zero.paint("0")
+	}
+}
+
+// BoolOptions are the glyphs and brushes used to show booleans.  A nil
+// Brush leaves its glyph plain.
+type BoolOptions struct {
+	True, False           string
+	TrueBrush, FalseBrush Brush
+}
+
+// DefaultBoolOptions shows true as a green `✓` and false as a red `✗`.  It
+// is used by Bool.
+var DefaultBoolOptions = BoolOptions{
+	True:       "✓",
+	False:      "✗",
+	TrueBrush:  Green,
+	FalseBrush: Red,
+}
+
+// Bool shows b according to DefaultBoolOptions, i.e:
+//
+//	fmt.Println(color.Bool(ok), "tests passed")
+func Bool(b bool) string {
+	return DefaultBoolOptions.Bool(b)
+}
+
+// Bool shows b with its glyph, painted with its brush.
+func (o BoolOptions) Bool(b bool) string {
+	if b {
+		return o.TrueBrush.paint(o.True)
+	}
+	return o.FalseBrush.paint(o.False)
+}
+
+// DurationOptions are the brushes used to paint durations by how they
+// compare to thresholds.  A nil Brush leaves the duration plain.
+type DurationOptions struct {
+	OK       Brush
+	Warn     Brush
+	Critical Brush
+}
+
+// DefaultDurationOptions paints durations green, yellow and red.  It is used
+// by Duration.
+var DefaultDurationOptions = DurationOptions{
+	OK:       Green,
+	Warn:     Yellow,
+	Critical: Red,
+}
+
+// Duration formats d and paints it according to DefaultDurationOptions, i.e:
+//
+//	fmt.Println("took", color.Duration(took, 100*time.Millisecond, time.Second))
+func Duration(d, warn, crit time.Duration) string {
+	return DefaultDurationOptions.Duration(d, warn, crit)
+}
+
+// Duration formats d and paints it with OK below warn, with Warn below crit
+// and with Critical from crit on.
+func (o DurationOptions) Duration(d, warn, crit time.Duration) string {
+	switch {
+	case d >= crit:
+		return o.Critical.paint(d.String())
+	case d >= warn:
+		return o.Warn.paint(d.String())
+	default:
+		return o.OK.paint(d.String())
+	}
+}
+
+// ExitCodeOptions are the brushes used to paint exit codes.  A nil Brush
+// leaves the code plain.
+type ExitCodeOptions struct {
+	Success Brush
+	Failure Brush
+}
+
+// DefaultExitCodeOptions paints 0 in green and the other codes in red.  It
+// is used by ColorizeExitCode.
+var DefaultExitCodeOptions = ExitCodeOptions{
+	Success: Green,
+	Failure: Red,
+}
+
+// ColorizeExitCode formats the exit code of a command and paints it
+// according to DefaultExitCodeOptions, i.e:
+//
+//	fmt.Println("exited with", color.ColorizeExitCode(cmd.ProcessState.ExitCode()))
+func ColorizeExitCode(code int) string {
+	return DefaultExitCodeOptions.ColorizeExitCode(code)
+}
+
+// ColorizeExitCode formats an exit code and paints it with Success when
+// it's 0, with Failure otherwise.
+func (o ExitCodeOptions) ColorizeExitCode(code int) string {
+	if code == 0 {
+		return o.Success.paint("0")
+	}
+	return o.Failure.paint(strconv.Itoa(code))
+}
+
+// AgeOptions are the brushes and thresholds used to paint how long ago
+// something happened.  A nil Brush leaves the age plain.
+type AgeOptions struct {
+	Recent, Older, Old Brush
+	// ages from OlderAfter on are Older, from OldAfter on Old
+	OlderAfter, OldAfter time.Duration
+}
+
+// DefaultAgeOptions paints ages green below an hour, light gray below a day
+// and dimmed dark gray from there.  It is used by ColorizeAge.
+var DefaultAgeOptions = AgeOptions{
+	Recent:     Green,
+	Older:      LightGray,
+	Old:        NewStyle("", DarkGrayPaint).WithAttributes(Dim).Brush(),
+	OlderAfter: time.Hour,
+	OldAfter:   24 * time.Hour,
+}
+
+// ColorizeAge tells how long before now t is, such as "2m ago", painted
+// according to DefaultAgeOptions, i.e:
+//
+//	fmt.Println(color.ColorizeAge(entry.Time, time.Now()), entry.Message)
+func ColorizeAge(t, now time.Time) string {
+	return DefaultAgeOptions.ColorizeAge(t, now)
+}
+
+// ColorizeAge tells how long before now t is, in its largest unit from
+// seconds to days, and paints it by how it compares to the thresholds.
+// Times after now are given as "in 2m" and painted as Recent.
+func (o AgeOptions) ColorizeAge(t, now time.Time) string {
+	age := now.Sub(t)
+	switch {
+	case age >= o.OldAfter:
+		return o.Old.paint(formatAge(age))
+	case age >= o.OlderAfter:
+		return o.Older.paint(formatAge(age))
+	default:
+		return o.Recent.paint(formatAge(age))
+	}
+}
+
+func formatAge(age time.Duration) string {
+	future := age < 0
+	if future {
+		age = -age
+	}
+
+	var text string
+	switch {
+	case age < time.Second:
+		return "just now"
+	case age < time.Minute:
+		text = strconv.Itoa(int(age/time.Second)) + "s"
+	case age < time.Hour:
+		text = strconv.Itoa(int(age/time.Minute)) + "m"
+	case age < 24*time.Hour:
+		text = strconv.Itoa(int(age/time.Hour)) + "h"
+	default:
+		text = strconv.Itoa(int(age/(24*time.Hour))) + "d"
+	}
+	if future {
+		return "in " + text
+	}
+	return text + " ago"
+}
+
+// BytesOptions are the brushes and thresholds used to paint byte counts by
+// their magnitude.  A nil Brush leaves the count plain.
+type BytesOptions struct {
+	Small, Medium, Large Brush
+	// counts from Warn on are Medium, from Crit on Large
+	Warn, Crit uint64
+}
+
+// DefaultBytesOptions paints counts green below 100 MB, yellow below 1 GB
+// and red from there.  It is used by Bytes.
+var DefaultBytesOptions = BytesOptions{
+	Small:  Green,
+	Medium: Yellow,
+	Large:  Red,
+	Warn:   100 * 1000 * 1000,
+	Crit:   1000 * 1000 * 1000,
+}
+
+// Bytes formats a count of bytes with decimal units and paints it according
+// to DefaultBytesOptions, i.e:
+//
+//	fmt.Println(color.Bytes(3500000)) // a green "3.5 MB"
+func Bytes(n uint64) string {
+	return DefaultBytesOptions.Bytes(n)
+}
+
+// Bytes formats a count of bytes with decimal units, up to EB, and paints
+// it by how it compares to the thresholds.
+func (o BytesOptions) Bytes(n uint64) string {
+	text := formatBytes(n)
+	switch {
+	case n >= o.Crit:
+		return o.Large.paint(text)
+	case n >= o.Warn:
+		return o.Medium.paint(text)
+	default:
+		return o.Small.paint(text)
+	}
+}
+
+func formatBytes(n uint64) string {
+	if n < 1000 {
+		return strconv.FormatUint(n, 10) + " B"
+	}
+	v, unit := float64(n), 0
+	for v >= 999.95 && unit < len(byteUnits) {
+		v /= 1000
+		unit++
+	}
+	return fmt.Sprintf("%.1f %s", v, byteUnits[unit-1])
+}
+
+var byteUnits = []string{"kB", "MB", "GB", "TB", "PB", "EB"}
@@ -0,0 +1,230 @@
+package color
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var signedTT = []struct {
+	n    float64
+	want string
+}{
+	{1.5, Green("+1.5")},
+	{42, Green("+42")},
+	{-0.25, Red("-0.25")},
+	{0, "0"},
+	{math.NaN(), "NaN"},
+	{math.Inf(1), Green("+Inf")},
+	{math.Inf(-1), Red("-Inf")},
+}
+
+func TestSigned(t *testing.T) {
+	for _, test := range signedTT {
+		if got := Signed(test.n); got != test.want {
+			t.Errorf("Signed(%v): Want %#v, got %#v", test.n, test.want, got)
+		}
+	}
+}
+
+func TestSignedInt(t *testing.T) {
+	for _, test := range []struct {
+		n    int
+		want string
+	}{
+		{3, Green("+3")},
+		{-3, Red("-3")},
+		{0, "0"},
+	} {
+		if got := SignedInt(test.n); got != test.want {
+			t.Errorf("SignedInt(%v): Want %#v, got %#v", test.n, test.want, got)
+		}
+	}
+}
+
+func TestSignOptions(t *testing.T) {
+	opts := SignOptions{
+		Positive: Blue,
+		Negative: Yellow,
+		Zero:     DarkGray,
+	}
+
+	for _, test := range []struct {
+		n    int
+		want string
+	}{
+		{3, Blue("+3")},
+		{-3, Yellow("-3")},
+		{0, DarkGray("0")},
+	} {
+		if got := opts.SignedInt(test.n); got != test.want {
+			t.Errorf("SignedInt(%v): Want %#v, got %#v", test.n, test.want, got)
+		}
+	}
+	if want, got := "NaN", opts.Signed(math.NaN()); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestBool(t *testing.T) {
+	if want, got := Green("✓"), Bool(true); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := Red("✗"), Bool(false); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	Disable()
+	defer Enable()
+	if want, got := "✓", Bool(true); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestBoolOptions(t *testing.T) {
+	opts := BoolOptions{True: "yes", False: "no", TrueBrush: Cyan}
+
+	if want, got := Cyan("yes"), opts.Bool(true); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := "no", opts.Bool(false); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+var durationTT = []struct {
+	d    time.Duration
+	want string
+}{
+	{30 * time.Millisecond, Green("30ms")},
+	{100 * time.Millisecond, Yellow("100ms")},
+	{999 * time.Millisecond, Yellow("999ms")},
+	{time.Second, Red("1s")},
+	{2500 * time.Millisecond, Red("2.5s")},
+}
+
+func TestDuration(t *testing.T) {
+	for _, test := range durationTT {
+		if got := Duration(test.d, 100*time.Millisecond, time.Second); got != test.want {
+			t.Errorf("Duration(%v): Want %#v, got %#v", test.d, test.want, got)
+		}
+	}
+}
+
+func TestDurationOptions(t *testing.T) {
+	opts := DurationOptions{Warn: Purple, Critical: DarkRed}
+
+	if want, got := "3ms", opts.Duration(3*time.Millisecond, time.Second, time.Minute); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := Purple("2s"), opts.Duration(2*time.Second, time.Second, time.Minute); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := DarkRed("1m0s"), opts.Duration(time.Minute, time.Second, time.Minute); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestColorizeExitCode(t *testing.T) {
+	for _, test := range []struct {
+		code int
+		want string
+	}{
+		{0, Green("0")},
+		{1, Red("1")},
+		{137, Red("137")},
+		{-1, Red("-1")},
+	} {
+		if got := ColorizeExitCode(test.code); got != test.want {
+			t.Errorf("%d: Want %#v, got %#v", test.code, test.want, got)
+		}
+	}
+
+	WithDisabled(func() {
+		if got := ColorizeExitCode(2); got != "2" {
+			t.Errorf("Want %#v, got %#v", "2", got)
+		}
+	})
+}
+
+func TestExitCodeOptions(t *testing.T) {
+	opts := ExitCodeOptions{Failure: Yellow}
+	if want, got := "0", opts.ColorizeExitCode(0); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := Yellow("3"), opts.ColorizeExitCode(3); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestColorizeAge(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	old := NewStyle("", DarkGrayPaint).WithAttributes(Dim).Brush()
+
+	for _, test := range []struct {
+		ago  time.Duration
+		want string
+	}{
+		{0, Green("just now")},
+		{45 * time.Second, Green("45s ago")},
+		{2*time.Minute + 10*time.Second, Green("2m ago")},
+		{3 * time.Hour, LightGray("3h ago")},
+		{50 * time.Hour, old("2d ago")},
+		{-5 * time.Minute, Green("in 5m")},
+	} {
+		if got := ColorizeAge(now.Add(-test.ago), now); got != test.want {
+			t.Errorf("%v ago: Want %#v, got %#v", test.ago, test.want, got)
+		}
+	}
+}
+
+func TestAgeOptions(t *testing.T) {
+	now := time.Now()
+	opts := AgeOptions{Older: Yellow, Old: Red, OlderAfter: time.Minute, OldAfter: time.Hour}
+
+	if want, got := "10s ago", opts.ColorizeAge(now.Add(-10*time.Second), now); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := Yellow("5m ago"), opts.ColorizeAge(now.Add(-5*time.Minute), now); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := Red("1h ago"), opts.ColorizeAge(now.Add(-time.Hour), now); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+var bytesTT = []struct {
+	n    uint64
+	want string
+}{
+	{0, Green("0 B")},
+	{999, Green("999 B")},
+	{1000, Green("1.0 kB")},
+	{3500000, Green("3.5 MB")},
+	{999950, Green("1.0 MB")},
+	{250 * 1000 * 1000, Yellow("250.0 MB")},
+	{1000 * 1000 * 1000, Red("1.0 GB")},
+	{42 * 1000 * 1000 * 1000 * 1000, Red("42.0 TB")},
+	{math.MaxUint64, Red("18.4 EB")},
+}
+
+func TestBytes(t *testing.T) {
+	for _, test := range bytesTT {
+		if got := Bytes(test.n); got != test.want {
+			t.Errorf("Bytes(%d): Want %#v, got %#v", test.n, test.want, got)
+		}
+	}
+}
+
+func TestBytesOptions(t *testing.T) {
+	opts := BytesOptions{Large: Purple, Warn: 10, Crit: 2000}
+	if want, got := "5 B", opts.Bytes(5); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := "100 B", opts.Bytes(100); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := Purple("2.0 kB"), opts.Bytes(2000); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
@@ -0,0 +1,40 @@
+package color
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Frame draws a box around the lines of content with box-drawing
+// characters painted with the style, one space away from the content:
+//
+//	┌───────┐
+//	│ hello │
+//	│ world │
+//	└───────┘
+//
+// Lines are measured by their visible length, so they can be colored, and
+// shorter ones are padded to the longest.  Each line ends with a newline, a
+// newline ending content is ignored.
+func Frame(content string, style Style) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	width := 0
+	for _, line := range lines {
+		if n := VisibleLen(line); n > width {
+			width = n
+		}
+	}
+
+	border := strings.Repeat("─", width+2)
+	side := style.colorize("│")
+	var buf bytes.Buffer
+	buf.WriteString(style.colorize("┌" + border + "┐"))
+	buf.WriteByte('\n')
+	for _, line := range lines {
+		buf.WriteString(side + " " + FitCell(line, width, AlignLeft) + " " + side)
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(style.colorize("└" + border + "┘"))
+	buf.WriteByte('\n')
+	return buf.String()
+}
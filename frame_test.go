@@ -0,0 +1,34 @@
+package color
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFrame(t *testing.T) {
+	style := NewStyle("", DarkGrayPaint)
+	b := style.Brush()
+
+	got := Frame("hello\n"+Red("colored")+" world\nhi\n", style)
+	want := b("┌───────────────┐") + "\n" +
+		b("│") + " hello         " + b("│") + "\n" +
+		b("│") + " " + Red("colored") + " world " + b("│") + "\n" +
+		b("│") + " hi            " + b("│") + "\n" +
+		b("└───────────────┘") + "\n"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	for i, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+		if n := VisibleLen(line); n != 17 {
+			t.Errorf("line %d: Want 17 visible runes, got %d", i, n)
+		}
+	}
+}
+
+func TestFrameEmpty(t *testing.T) {
+	want := "┌──┐\n│  │\n└──┘\n"
+	if got := Frame("", Style{}); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
@@ -0,0 +1,50 @@
+package color
+
+// GitStatusStyles gives the style of each status letter of the short
+// format of `git status`, as used by ColorizeGitStatus.  It can be changed
+// to restyle letters or to add some.
+var GitStatusStyles = map[byte]Style{
+	'M': NewStyle("", YellowPaint),   // modified
+	'T': NewStyle("", YellowPaint),   // type changed
+	'A': NewStyle("", GreenPaint),    // added
+	'D': NewStyle("", RedPaint),      // deleted
+	'R': NewStyle("", CyanPaint),     // renamed
+	'C': NewStyle("", CyanPaint),     // copied
+	'U': NewStyle("", PurplePaint),   // unmerged
+	'?': NewStyle("", RedPaint),      // untracked
+	'!': NewStyle("", DarkGrayPaint), // ignored
+}
+
+// ColorizeGitStatus paints the two status letters starting a line of
+// `git status --short`, each with its style in GitStatusStyles, i.e:
+//
+//	for _, line := range strings.Split(out, "\n") {
+//		fmt.Println(color.ColorizeGitStatus(line))
+//	}
+//
+// The file names are left plain, and so are lines not starting with a
+// status and a space.
+func ColorizeGitStatus(line string) string {
+	if len(line) < 3 || line[2] != ' ' || !isGitStatus(line[0]) || !isGitStatus(line[1]) {
+		return line
+	}
+	status := ""
+	for i := 0; i < 2; i++ {
+		if style, ok := GitStatusStyles[line[i]]; ok {
+			status += style.colorize(line[i : i+1])
+		} else {
+			status += line[i : i+1]
+		}
+	}
+	return status + line[2:]
+}
+
+// isGitStatus tells if c can be a letter of a status: one of
+// GitStatusStyles or a space, for no change.
+func isGitStatus(c byte) bool {
+	if c == ' ' {
+		return true
+	}
+	_, ok := GitStatusStyles[c]
+	return ok
+}
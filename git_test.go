@@ -0,0 +1,36 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestColorizeGitStatus(t *testing.T) {
+	for _, test := range []struct {
+		line, want string
+	}{
+		{" M color.go", " " + Yellow("M") + " color.go"},
+		{"M  color.go", Yellow("M") + "  color.go"},
+		{"A  git.go", Green("A") + "  git.go"},
+		{"AM git.go", Green("A") + Yellow("M") + " git.go"},
+		{" D old.go", " " + Red("D") + " old.go"},
+		{"?? notes.txt", Red("?") + Red("?") + " notes.txt"},
+		{"R  a.go -> b.go", Cyan("R") + "  a.go -> b.go"},
+		{"On branch main", "On branch main"},
+		{"", ""},
+	} {
+		if got := ColorizeGitStatus(test.line); got != test.want {
+			t.Errorf("%q: Want %#v, got %#v", test.line, test.want, got)
+		}
+	}
+}
+
+func TestGitStatusStylesOverride(t *testing.T) {
+	old := GitStatusStyles['?']
+	defer func() { GitStatusStyles['?'] = old }()
+
+	GitStatusStyles['?'] = NewStyle("", DarkGrayPaint)
+	want := DarkGray("?") + DarkGray("?") + " tmp"
+	if got := ColorizeGitStatus("?? tmp"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
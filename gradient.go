@@ -0,0 +1,216 @@
+package color
+
+import (
+	"bytes"
+	"math"
+	"unicode/utf8"
+)
+
+// Gradient paints the runes of s with foregrounds going smoothly from the
+// from paint to the to paint.
+func Gradient(from, to Paint, s string) string {
+	return MultiGradient([]Paint{from, to}, s)
+}
+
+// MultiGradient paints the runes of s with foregrounds going smoothly
+// through each of the stops, spread evenly over the string, i.e:
+//
+//	fmt.Println(color.MultiGradient([]color.Paint{color.RedPaint, color.YellowPaint, color.GreenPaint}, "Welcome!"))
+//
+// A single stop paints the whole string with it.
+func MultiGradient(stops []Paint, s string) string {
+	if s == "" || len(stops) == 0 || !Enabled() {
+		return s
+	}
+	if len(stops) == 1 {
+		return NewStyle("", stops[0]).colorize(s)
+	}
+
+	n := utf8.RuneCountInString(s)
+	var buf bytes.Buffer
+	var last Paint
+	i := 0
+	for _, r := range s {
+		if p := gradientAt(stops, i, n); p != last {
+			buf.WriteString(sgr(string(p)))
+			last = p
+		}
+		buf.WriteRune(r)
+		i++
+	}
+	buf.WriteString(ResetCode())
+	return buf.String()
+}
+
+// GradientBackground paints the background behind the runes of s going
+// smoothly from the from paint to the to paint, like a strip behind the
+// text.  The text is painted black or white, whichever reads best on each
+// background.
+func GradientBackground(from, to Paint, s string) string {
+	if s == "" || !Enabled() {
+		return s
+	}
+
+	stops := []Paint{from, to}
+	n := utf8.RuneCountInString(s)
+	var buf bytes.Buffer
+	var last, lastFg Paint
+	i := 0
+	for _, r := range s {
+		if p := gradientAt(stops, i, n); p != last {
+			// the foreground first, the `0;` of dark paints would reset
+			// the background
+			if fg := ReadableForeground(p); fg != lastFg {
+				buf.WriteString(sgr(string(fg)))
+				lastFg = fg
+			}
+			if back, ok := p.BackgroundCode(); ok {
+				buf.WriteString(sgr(back))
+			}
+			last = p
+		}
+		buf.WriteRune(r)
+		i++
+	}
+	buf.WriteString(ResetCode())
+	return buf.String()
+}
+
+// GradientLines paints each of the lines with a foreground going smoothly
+// from the from paint on the first line to the to paint on the last one,
+// a vertical gradient for banners, i.e:
+//
+//	for _, line := range color.GradientLines(color.BluePaint, color.PurplePaint, banner) {
+//		fmt.Println(line)
+//	}
+//
+// A single line is painted with from, and empty lines are left empty.
+func GradientLines(from, to Paint, lines []string) []string {
+	stops := []Paint{from, to}
+	painted := make([]string, len(lines))
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		painted[i] = NewStyle("", gradientAt(stops, i, len(lines))).colorize(line)
+	}
+	return painted
+}
+
+// ColumnGradient paints the runes of the lines with foregrounds going
+// smoothly from the from paint on the first column to the to paint on the
+// last column of the longest line, so that each column has the same color
+// on every line, as vertical stripes in ANSI art.  Shorter lines only go
+// part of the way, and empty lines are left empty.  The colors already in
+// the lines are kept, except for their foregrounds.
+func ColumnGradient(lines []string, from, to Paint) []string {
+	width := 0
+	for _, line := range lines {
+		if n := VisibleLen(line); n > width {
+			width = n
+		}
+	}
+
+	stops := []Paint{from, to}
+	painted := make([]string, len(lines))
+	for i, line := range lines {
+		if line == "" || !Enabled() {
+			painted[i] = line
+			continue
+		}
+
+		var buf bytes.Buffer
+		var last Paint
+		col := 0
+		eachToken(line, func(tok string, escape bool) {
+			if escape {
+				buf.WriteString(tok)
+				// the sequence could have changed the foreground
+				last = ""
+				return
+			}
+			for _, r := range tok {
+				if p := gradientAt(stops, col, width); p != last {
+					buf.WriteString(sgr(string(p)))
+					last = p
+				}
+				buf.WriteRune(r)
+				col++
+			}
+		})
+		buf.WriteString(ResetCode())
+		painted[i] = buf.String()
+	}
+	return painted
+}
+
+// bayer4 holds the thresholds of a 4 cells ordered dithering pattern.
+var bayer4 = [4]float64{0.125, 0.625, 0.375, 0.875}
+
+// Gradient256Dithered is like Gradient for terminals with 256 colors: the
+// colors between two levels of the color cube are approximated by mixing
+// runes of both levels with ordered dithering, rather than rounding them
+// all to the nearest one, which shows bands on long strings.  Paints
+// without RGB value leave s plain.
+func Gradient256Dithered(from, to Paint, s string) string {
+	fr, fg, fb, fok := from.RGB()
+	tr, tg, tb, tok := to.RGB()
+	if s == "" || !fok || !tok || !Enabled() {
+		return s
+	}
+
+	n := utf8.RuneCountInString(s)
+	var buf bytes.Buffer
+	var last Paint
+	i := 0
+	for _, r := range s {
+		t := 0.0
+		if n > 1 {
+			t = float64(i) / float64(n-1)
+		}
+		threshold := bayer4[i%len(bayer4)]
+		p := Cube(
+			ditherLevel(float64(fr)+(float64(tr)-float64(fr))*t, threshold),
+			ditherLevel(float64(fg)+(float64(tg)-float64(fg))*t, threshold),
+			ditherLevel(float64(fb)+(float64(tb)-float64(fb))*t, threshold),
+		)
+		if p != last {
+			buf.WriteString(sgr(string(p)))
+			last = p
+		}
+		buf.WriteRune(r)
+		i++
+	}
+	buf.WriteString(ResetCode())
+	return buf.String()
+}
+
+// ditherLevel gives the level of the color cube, from 0 to 5, for a
+// component: the upper of the two levels around v when v is further than
+// threshold of the way between them, the lower one otherwise.
+func ditherLevel(v, threshold float64) uint8 {
+	k := 0
+	for k < len(cubeLevels)-2 && v >= float64(cubeLevels[k+1]) {
+		k++
+	}
+	lo, hi := float64(cubeLevels[k]), float64(cubeLevels[k+1])
+	if (v-lo)/(hi-lo) > threshold {
+		return uint8(k + 1)
+	}
+	return uint8(k)
+}
+
+// gradientAt gives the paint of the i-th of n cells of a gradient going
+// through stops.
+func gradientAt(stops []Paint, i, n int) Paint {
+	if n <= 1 {
+		return Blend(stops[0], stops[0], 0)
+	}
+
+	pos := float64(i) / float64(n-1) * float64(len(stops)-1)
+	k := int(math.Floor(pos))
+	if k >= len(stops)-1 {
+		k = len(stops) - 2
+	}
+	return Blend(stops[k], stops[k+1], pos-float64(k))
+}
@@ -0,0 +1,194 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestGradient(t *testing.T) {
+	spans := Parse(Gradient(BlackPaint, WhitePaint, "abc"))
+
+	want := []Span{
+		{NewStyle("", PaintRGB(0, 0, 0)), "a"},
+		{NewStyle("", PaintRGB(128, 128, 128)), "b"},
+		{NewStyle("", PaintRGB(255, 255, 255)), "c"},
+	}
+	if len(spans) != len(want) {
+		t.Fatalf("Want %#v, got %#v", want, spans)
+	}
+	for i := range want {
+		if spans[i] != want[i] {
+			t.Errorf("Want %#v, got %#v", want[i], spans[i])
+		}
+	}
+}
+
+func TestMultiGradient(t *testing.T) {
+	red, green, blue := PaintRGB(255, 0, 0), PaintRGB(0, 255, 0), PaintRGB(0, 0, 255)
+	spans := Parse(MultiGradient([]Paint{red, green, blue}, "abcdéfghi"))
+
+	if len(spans) != 9 {
+		t.Fatalf("Want a span per rune, got %#v", spans)
+	}
+	for _, check := range []struct {
+		i    int
+		text string
+		p    Paint
+	}{
+		{0, "a", red},
+		{2, "c", PaintRGB(128, 128, 0)},
+		{4, "é", green},
+		{8, "i", blue},
+	} {
+		want := Span{NewStyle("", check.p), check.text}
+		if spans[check.i] != want {
+			t.Errorf("rune %d: Want %#v, got %#v", check.i, want, spans[check.i])
+		}
+	}
+}
+
+func TestMultiGradientEdgeCases(t *testing.T) {
+	if got := MultiGradient([]Paint{RedPaint, BluePaint}, ""); got != "" {
+		t.Errorf("Want %#v, got %#v", "", got)
+	}
+
+	want := Red("solid")
+	if got := MultiGradient([]Paint{RedPaint}, "solid"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// more stops than runes
+	spans := Parse(MultiGradient([]Paint{BlackPaint, RedPaint, GreenPaint, WhitePaint}, "ab"))
+	want2 := []Span{
+		{NewStyle("", PaintRGB(0, 0, 0)), "a"},
+		{NewStyle("", PaintRGB(255, 255, 255)), "b"},
+	}
+	if len(spans) != 2 || spans[0] != want2[0] || spans[1] != want2[1] {
+		t.Errorf("Want %#v, got %#v", want2, spans)
+	}
+}
+
+func TestGradientBackground(t *testing.T) {
+	got := GradientBackground(BlackPaint, WhitePaint, "abc")
+
+	want := "\033[97m" + "\033[48;2;0;0;0m" + "a" +
+		"\033[30m" + "\033[48;2;128;128;128m" + "b" +
+		"\033[48;2;255;255;255m" + "c" +
+		"\033[0m"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	spans := Parse(got)
+	if len(spans) != 3 || spans[2].Style.Background() != PaintRGB(255, 255, 255) {
+		t.Errorf("Want a background per rune, got %#v", spans)
+	}
+}
+
+func TestGradientBackgroundDisabled(t *testing.T) {
+	Disable()
+	defer Enable()
+	if got := GradientBackground(BlackPaint, WhitePaint, "abc"); got != "abc" {
+		t.Errorf("Want %#v, got %#v", "abc", got)
+	}
+}
+
+func TestGradientLines(t *testing.T) {
+	lines := GradientLines(BlackPaint, WhitePaint, []string{"top", "", "bottom"})
+
+	want := []string{
+		NewStyle("", PaintRGB(0, 0, 0)).Brush()("top"),
+		"",
+		NewStyle("", PaintRGB(255, 255, 255)).Brush()("bottom"),
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Want %#v, got %#v", want, lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Want %#v, got %#v", want[i], lines[i])
+		}
+	}
+
+	single := GradientLines(BlackPaint, WhitePaint, []string{"only"})
+	if want := NewStyle("", PaintRGB(0, 0, 0)).Brush()("only"); single[0] != want {
+		t.Errorf("Want %#v, got %#v", want, single[0])
+	}
+
+	if got := GradientLines(BlackPaint, WhitePaint, nil); len(got) != 0 {
+		t.Errorf("Want no lines, got %#v", got)
+	}
+}
+
+func TestGradient256Dithered(t *testing.T) {
+	// halfway between the red levels 1 and 2 of the color cube
+	mid := PaintRGB(115, 0, 0)
+	spans := Parse(Gradient256Dithered(mid, mid, "abcd"))
+
+	lo, hi := NewStyle("", Cube(1, 0, 0)), NewStyle("", Cube(2, 0, 0))
+	want := []Span{{hi, "a"}, {lo, "b"}, {hi, "c"}, {lo, "d"}}
+	if len(spans) != len(want) {
+		t.Fatalf("Want %#v, got %#v", want, spans)
+	}
+	for i := range want {
+		if spans[i].Text != want[i].Text || spans[i].Style.code != want[i].Style.code {
+			t.Errorf("Want %#v, got %#v", want[i], spans[i])
+		}
+	}
+}
+
+func TestGradient256DitheredEnds(t *testing.T) {
+	// colors of the cube are never dithered
+	got := Gradient256Dithered(PaintRGB(0, 0, 0), PaintRGB(255, 255, 255), "ab")
+	want := "\033[38;5;16ma\033[38;5;231mb\033[0m"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	if got := Gradient256Dithered("", BluePaint, "ab"); got != "ab" {
+		t.Errorf("Want %#v, got %#v", "ab", got)
+	}
+}
+
+func TestColumnGradient(t *testing.T) {
+	lines := ColumnGradient([]string{"abcde", "xy", "", Red("12") + "345"}, BlackPaint, WhitePaint)
+
+	// the style of each column of each line
+	var columns [][]Style
+	for _, line := range lines {
+		var styles []Style
+		for _, span := range Parse(line) {
+			for range span.Text {
+				styles = append(styles, span.Style)
+			}
+		}
+		columns = append(columns, styles)
+	}
+
+	if n := len(columns[0]); n != 5 {
+		t.Fatalf("Want 5 columns, got %d", n)
+	}
+	if want, got := NewStyle("", PaintRGB(0, 0, 0)), columns[0][0]; got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := NewStyle("", PaintRGB(255, 255, 255)), columns[0][4]; got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	for col := 0; col < 2; col++ {
+		if columns[1][col] != columns[0][col] {
+			t.Errorf("column %d: Want %#v, got %#v", col, columns[0][col], columns[1][col])
+		}
+	}
+	for col := 0; col < 5; col++ {
+		// the bold of Red is kept, but not its foreground
+		want := columns[0][col]
+		if col < 2 {
+			want = want.WithAttributes(Bold)
+		}
+		if got := columns[3][col]; got != want {
+			t.Errorf("column %d: Want %#v, got %#v", col, want, got)
+		}
+	}
+	if lines[2] != "" {
+		t.Errorf("Want the empty line left empty, got %#v", lines[2])
+	}
+}
@@ -0,0 +1,39 @@
+package color
+
+import (
+	"hash/fnv"
+	"strings"
+)
+
+// Hash picks a paint of the palette from the key, always the same one for
+// the same key, so that recurring values such as request IDs or user names
+// can be told apart at a glance.  It gives the empty paint if the palette
+// is empty.
+func Hash(key string, palette []Paint) Paint {
+	if len(palette) == 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return palette[h.Sum32()%uint32(len(palette))]
+}
+
+// hostPalette holds colors of the 256 colors palette with hues spread
+// around the wheel and a luminance between 0.15 and 0.25, dark enough to
+// read on a light background and light enough to read on a dark one.
+var hostPalette = []Paint{
+	Index(167), Index(166), Index(130), Index(100),
+	Index(64), Index(28), Index(30), Index(32),
+	Index(62), Index(98), Index(164), Index(162),
+}
+
+// ColorizeHost colors a host name or an IP address, always with the same
+// color for the same host, the way `stern` colors pods, i.e:
+//
+//	log.Printf("%s: connection reset", color.ColorizeHost(addr))
+//
+// Host names are not case sensitive, so neither is the color.
+func ColorizeHost(host string) string {
+	p := Hash(strings.ToLower(host), hostPalette)
+	return NewStyle("", p).colorize(host)
+}
@@ -0,0 +1,52 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	palette := []Paint{RedPaint, GreenPaint, BluePaint}
+	if want, got := Hash("req-42", palette), Hash("req-42", palette); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	seen := map[Paint]bool{}
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		seen[Hash(key, palette)] = true
+	}
+	if want, got := len(palette), len(seen); got != want {
+		t.Errorf("Want %#v paints used, got %#v", want, got)
+	}
+
+	if want, got := Paint(""), Hash("req-42", nil); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestColorizeHost(t *testing.T) {
+	for _, host := range []string{"db-1.internal", "10.0.0.12", "::1"} {
+		want := ColorizeHost(host)
+		for i := 0; i < 3; i++ {
+			if got := ColorizeHost(host); got != want {
+				t.Errorf("Want %#v, got %#v", want, got)
+			}
+		}
+		wantCode := NewStyle("", Hash(host, hostPalette)).code
+		if want, got := wantCode+host+"\033[0m", ColorizeHost(host); got != want {
+			t.Errorf("Want %#v, got %#v", want, got)
+		}
+	}
+
+	wantCode := NewStyle("", Hash("db-1.internal", hostPalette)).code
+	if want, got := wantCode+"DB-1.Internal"+"\033[0m", ColorizeHost("DB-1.Internal"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestHostPaletteReadable(t *testing.T) {
+	for _, p := range hostPalette {
+		if l, _ := Luminance(p); l < 0.15 || l > 0.25 {
+			t.Errorf("Want luminance of %#v within [0.15, 0.25], got %v", p, l)
+		}
+	}
+}
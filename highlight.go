@@ -0,0 +1,278 @@
+package color
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Highlight paints each match of re in s with the style.
+func Highlight(re *regexp.Regexp, s string, style Style) string {
+	var buf bytes.Buffer
+	pos := 0
+	for _, m := range re.FindAllStringIndex(s, -1) {
+		if m[0] == m[1] {
+			continue
+		}
+		buf.WriteString(s[pos:m[0]])
+		buf.WriteString(style.colorize(s[m[0]:m[1]]))
+		pos = m[1]
+	}
+	buf.WriteString(s[pos:])
+	return buf.String()
+}
+
+// HighlightGroups paints the capture groups of each match of re in s, the
+// first group with the first style, the second with the second one, and so
+// on.  The rest of the matches is left plain, and so are groups without a
+// style or that didn't match anything.  When groups overlap, the one that
+// comes first wins, i.e:
+//
+//	re := regexp.MustCompile(`(\d{2}:\d{2}) (\w+)`)
+//	color.HighlightGroups(re, line, timeStyle, levelStyle)
+func HighlightGroups(re *regexp.Regexp, s string, styles ...Style) string {
+	var buf bytes.Buffer
+	pos := 0
+	for _, m := range re.FindAllStringSubmatchIndex(s, -1) {
+		for g := 1; g < len(m)/2 && g <= len(styles); g++ {
+			start, end := m[2*g], m[2*g+1]
+			if start < pos || start == end {
+				continue
+			}
+			buf.WriteString(s[pos:start])
+			buf.WriteString(styles[g-1].colorize(s[start:end]))
+			pos = end
+		}
+	}
+	buf.WriteString(s[pos:])
+	return buf.String()
+}
+
+// HighlightRanges paints the [start, end) byte ranges of the plain string s
+// with the style, such as the ranges of diagnostics.  Overlapping and
+// adjacent ranges are painted as one, and ranges are clipped to s.
+func HighlightRanges(s string, ranges [][2]int, style Style) string {
+	var clipped [][2]int
+	for _, r := range ranges {
+		start, end := clip(r[0], len(s)), clip(r[1], len(s))
+		if start < end {
+			clipped = append(clipped, [2]int{start, end})
+		}
+	}
+	sort.Slice(clipped, func(i, j int) bool { return clipped[i][0] < clipped[j][0] })
+
+	var buf bytes.Buffer
+	pos := 0
+	for i := 0; i < len(clipped); i++ {
+		start, end := clipped[i][0], clipped[i][1]
+		for i+1 < len(clipped) && clipped[i+1][0] <= end {
+			i++
+			if clipped[i][1] > end {
+				end = clipped[i][1]
+			}
+		}
+		buf.WriteString(s[pos:start])
+		buf.WriteString(style.colorize(s[start:end]))
+		pos = end
+	}
+	buf.WriteString(s[pos:])
+	return buf.String()
+}
+
+// Rule is a pattern to highlight and the style to paint its matches with.
+type Rule struct {
+	Pattern *regexp.Regexp
+	Style   Style
+}
+
+// HighlightRules paints the matches of each of the rules in the plain
+// string s, in order: a match overlapping one of an earlier rule is left
+// alone, so the first rules take precedence, i.e:
+//
+//	rules := []color.Rule{
+//		{regexp.MustCompile(`"[^"]*"`), stringStyle},
+//		{regexp.MustCompile(`\b(ERROR|WARN)\b`), levelStyle},
+//	}
+//	fmt.Println(color.HighlightRules(line, rules)) // no level painted inside quotes
+func HighlightRules(s string, rules []Rule) string {
+	type span struct {
+		start, end int
+		style      Style
+	}
+	var spans []span
+	overlaps := func(start, end int) bool {
+		for _, sp := range spans {
+			if start < sp.end && sp.start < end {
+				return true
+			}
+		}
+		return false
+	}
+	for _, rule := range rules {
+		var matched []span
+		for _, m := range rule.Pattern.FindAllStringIndex(s, -1) {
+			if m[0] < m[1] && !overlaps(m[0], m[1]) {
+				matched = append(matched, span{m[0], m[1], rule.Style})
+			}
+		}
+		spans = append(spans, matched...)
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	var buf bytes.Buffer
+	pos := 0
+	for _, sp := range spans {
+		buf.WriteString(s[pos:sp.start])
+		buf.WriteString(sp.style.colorize(s[sp.start:sp.end]))
+		pos = sp.end
+	}
+	buf.WriteString(s[pos:])
+	return buf.String()
+}
+
+// HighlightRuneRange paints the [start, end) range of runes of the plain
+// string s with the style, for positions counted in characters rather than
+// bytes, such as the columns of an editor.  The range is clipped to s.
+func HighlightRuneRange(s string, start, end int, style Style) string {
+	i, j := runeOffset(s, start), runeOffset(s, end)
+	if i >= j {
+		return s
+	}
+	return s[:i] + style.colorize(s[i:j]) + s[j:]
+}
+
+func clip(i, n int) int {
+	switch {
+	case i < 0:
+		return 0
+	case i > n:
+		return n
+	}
+	return i
+}
+
+// literal matches the candidates for HighlightLiterals, which then checks
+// that they stand alone.
+var literal = regexp.MustCompile(`-?[0-9]+(?:\.[0-9]+)?(?:[eE][+-]?[0-9]+)?|true|false|null`)
+
+// HighlightLiterals paints the booleans, nulls and numbers standing alone in
+// s, such as the values of a plain log line, with DefaultTheme.
+func HighlightLiterals(s string) string {
+	return DefaultTheme.HighlightLiterals(s)
+}
+
+// HighlightLiterals paints the booleans, nulls and numbers standing alone in
+// s with the "bool", "null" and "number" roles of the theme.  Literals that
+// are part of a longer word or number are left plain, so neither `truent`,
+// `v2` nor `10.0.0.1` are painted.  Already colored text is left as it is.
+func (t Theme) HighlightLiterals(s string) string {
+	var buf bytes.Buffer
+	colored := false
+	eachToken(s, func(tok string, escape bool) {
+		if escape {
+			if _, ok := sgrParams([]byte(tok)); ok {
+				colored = !isReset(tok)
+			}
+			buf.WriteString(tok)
+			return
+		}
+		if colored {
+			buf.WriteString(tok)
+			return
+		}
+		pos := 0
+		for _, m := range literal.FindAllStringIndex(tok, -1) {
+			if !standsAlone(tok, m[0], m[1]) {
+				continue
+			}
+			role := "number"
+			switch tok[m[0]:m[1]] {
+			case "true", "false":
+				role = "bool"
+			case "null":
+				role = "null"
+			}
+			buf.WriteString(tok[pos:m[0]])
+			buf.WriteString(t.paint(role, tok[m[0]:m[1]]))
+			pos = m[1]
+		}
+		buf.WriteString(tok[pos:])
+	})
+	return buf.String()
+}
+
+// standsAlone tells if s[i:j] isn't glued to letters, digits or dots around
+// it.  A dot ending a sentence is fine.
+func standsAlone(s string, i, j int) bool {
+	if i > 0 {
+		r, _ := utf8.DecodeLastRuneInString(s[:i])
+		if isWordRune(r) || r == '.' || (r == '-' && s[i] != '-') {
+			return false
+		}
+	}
+	if j < len(s) {
+		r, n := utf8.DecodeRuneInString(s[j:])
+		if r == '.' && j+n < len(s) {
+			r, _ = utf8.DecodeRuneInString(s[j+n:])
+		}
+		if isWordRune(r) || r == '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// GrepColorize lays out the lines matching re like `grep -n --color`
+// does, with ctx lines of context around each of them: matches in red,
+// the numbers of matching lines in green and those of context lines in
+// dark gray, followed by `:` and `-` respectively.  Groups of lines that
+// aren't contiguous are separated by a `--` line when there is context.
+// Each line ends with a newline, and nothing is given without matches.
+func GrepColorize(re *regexp.Regexp, lines []string, ctx int) string {
+	if ctx < 0 {
+		ctx = 0
+	}
+	shown := make([]bool, len(lines))
+	matched := make([]bool, len(lines))
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		matched[i] = true
+		for j := i - ctx; j <= i+ctx; j++ {
+			if j >= 0 && j < len(lines) {
+				shown[j] = true
+			}
+		}
+	}
+
+	match := NewStyle("", RedPaint)
+	var buf bytes.Buffer
+	last := -1
+	for i, line := range lines {
+		if !shown[i] {
+			continue
+		}
+		if ctx > 0 && last >= 0 && i > last+1 {
+			buf.WriteString(Cyan("--"))
+			buf.WriteByte('\n')
+		}
+		last = i
+
+		n := strconv.Itoa(i + 1)
+		if matched[i] {
+			buf.WriteString(Green(n) + Cyan(":") + Highlight(re, line, match))
+		} else {
+			buf.WriteString(DarkGray(n) + Cyan("-") + line)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
@@ -0,0 +1,185 @@
+package color
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	re := regexp.MustCompile(`\d+`)
+
+	want := "took " + Red("12") + "ms, " + Red("3") + " retries"
+	if got := Highlight(re, "took 12ms, 3 retries", NewStyle("", RedPaint)); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	if got := Highlight(re, "nothing", NewStyle("", RedPaint)); got != "nothing" {
+		t.Errorf("Want %#v, got %#v", "nothing", got)
+	}
+}
+
+var highlightGroupsTT = []struct {
+	name string
+	re   string
+	s    string
+	want string
+}{
+	{"two groups", `(\d\d:\d\d) (\w+):`, "at 12:30 WARN: disk full",
+		"at " + Cyan("12:30") + " " + Yellow("WARN") + ": disk full"},
+	{"many matches", `(\w)=(\d)`, "a=1 b=2",
+		Cyan("a") + "=" + Yellow("1") + " " + Cyan("b") + "=" + Yellow("2")},
+	{"optional group", `(\w+)(!)?`, "hi",
+		Cyan("hi")},
+	{"empty group", `(\w*)=(\w+)`, "=v",
+		"=" + Yellow("v")},
+	{"nested groups", `((\w)\w)`, "ab",
+		Cyan("ab")},
+	{"more groups than styles", `(\w)(\w)(\w)`, "abc",
+		Cyan("a") + Yellow("b") + "c"},
+}
+
+func TestHighlightGroups(t *testing.T) {
+	styles := []Style{NewStyle("", CyanPaint), NewStyle("", YellowPaint)}
+	for _, test := range highlightGroupsTT {
+		re := regexp.MustCompile(test.re)
+		if got := HighlightGroups(re, test.s, styles...); got != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, got)
+		}
+	}
+}
+
+var highlightRangesTT = []struct {
+	name   string
+	ranges [][2]int
+	want   string
+}{
+	{"none", nil, "let x = y + 1"},
+	{"disjoint", [][2]int{{8, 9}, {4, 5}}, "let [x] = [y] + 1"},
+	{"adjacent", [][2]int{{4, 6}, {6, 7}}, "let [x =] y + 1"},
+	{"overlapping", [][2]int{{4, 9}, {6, 13}, {5, 7}}, "let [x = y + 1]"},
+	{"out of bounds", [][2]int{{-3, 3}, {12, 40}}, "[let] x = y + [1]"},
+	{"empty and reversed", [][2]int{{4, 4}, {9, 8}, {20, 30}}, "let x = y + 1"},
+}
+
+func TestHighlightRanges(t *testing.T) {
+	style := NewStyle("", RedPaint)
+	expand := strings.NewReplacer("[", style.code, "]", ResetCode()).Replace
+
+	for _, test := range highlightRangesTT {
+		if got, want := HighlightRanges("let x = y + 1", test.ranges, style), expand(test.want); got != want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, want, got)
+		}
+	}
+}
+
+func TestHighlightLiterals(t *testing.T) {
+	b := func(s string) string { return DefaultTheme.paint("bool", s) }
+	n := func(s string) string { return DefaultTheme.paint("number", s) }
+	null := DefaultTheme.paint("null", "null")
+
+	for _, test := range []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"plain", "nothing here", "nothing here"},
+		{"bool", "cached=true", "cached=" + b("true")},
+		{"glued", "truent nullable falsey", "truent nullable falsey"},
+		{"null and numbers", "user null after 3 tries, -1.5e3 left",
+			"user " + null + " after " + n("3") + " tries, " + n("-1.5e3") + " left"},
+		{"in words", "v2 api2 x_1 12ms", "v2 api2 x_1 12ms"},
+		{"dotted", "at 10.0.0.1 the end 42.", "at 10.0.0.1 the end " + n("42") + "."},
+		{"dashed", "on 2024-01-02 a-1", "on 2024-01-02 a-1"},
+		{"colored", Red("true") + " false", Red("true") + " " + b("false")},
+	} {
+		if got := HighlightLiterals(test.s); got != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestHighlightRules(t *testing.T) {
+	quoted, level := NewStyle("", DarkGreenPaint), NewStyle("", RedPaint)
+	rules := []Rule{
+		{regexp.MustCompile(`"[^"]*"`), quoted},
+		{regexp.MustCompile(`\b(ERROR|WARN)\b`), level},
+	}
+
+	want := level.Brush()("ERROR") + " got " + quoted.Brush()(`"WARN: low disk"`) + ", " + level.Brush()("WARN") + " again"
+	if got := HighlightRules(`ERROR got "WARN: low disk", WARN again`, rules); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// the earlier rule wins even when the later one matches first
+	rules = []Rule{
+		{regexp.MustCompile(`b+c`), quoted},
+		{regexp.MustCompile(`ab`), level},
+	}
+	want = "a" + quoted.Brush()("bbc")
+	if got := HighlightRules("abbc", rules); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	if got := HighlightRules("plain", nil); got != "plain" {
+		t.Errorf("Want %#v, got %#v", "plain", got)
+	}
+}
+
+func TestHighlightRuneRange(t *testing.T) {
+	style := NewStyle("", RedPaint)
+	red := style.Brush()
+
+	for _, test := range []struct {
+		s          string
+		start, end int
+		want       string
+	}{
+		{"héllo wörld", 1, 4, "h" + red("éll") + "o wörld"},
+		{"日本語のテキスト", 3, 8, "日本語" + red("のテキスト")},
+		{"naïve", 2, 3, "na" + red("ï") + "ve"},
+		{"naïve", -2, 99, red("naïve")},
+		{"naïve", 3, 3, "naïve"},
+		{"naïve", 4, 2, "naïve"},
+	} {
+		if got := HighlightRuneRange(test.s, test.start, test.end, style); got != test.want {
+			t.Errorf("%q [%d, %d): Want %#v, got %#v", test.s, test.start, test.end, test.want, got)
+		}
+	}
+}
+
+func TestGrepColorize(t *testing.T) {
+	lines := []string{
+		"package main",
+		"",
+		"func main() {",
+		"	panic(err)",
+		"}",
+		"",
+		"func helper() {",
+		"	return",
+		"}",
+		"// panic at will",
+	}
+	re := regexp.MustCompile(`panic`)
+
+	want := DarkGray("3") + Cyan("-") + "func main() {\n" +
+		Green("4") + Cyan(":") + "	" + Red("panic") + "(err)\n" +
+		DarkGray("5") + Cyan("-") + "}\n" +
+		Cyan("--") + "\n" +
+		DarkGray("9") + Cyan("-") + "}\n" +
+		Green("10") + Cyan(":") + "// " + Red("panic") + " at will\n"
+	if got := GrepColorize(re, lines, 1); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want = Green("4") + Cyan(":") + "	" + Red("panic") + "(err)\n" +
+		Green("10") + Cyan(":") + "// " + Red("panic") + " at will\n"
+	if got := GrepColorize(re, lines, 0); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	if got := GrepColorize(regexp.MustCompile(`nothing`), lines, 2); got != "" {
+		t.Errorf("Want %#v, got %#v", "", got)
+	}
+}
@@ -0,0 +1,87 @@
+package color
+
+import (
+	"bytes"
+)
+
+// HighlightJSON highlights JSON data with DefaultTheme, keeping its
+// formatting.
+func HighlightJSON(data []byte) string {
+	return DefaultTheme.HighlightJSON(data)
+}
+
+// HighlightJSON highlights JSON data, keeping its formatting.  Keys,
+// strings, numbers, booleans, null and punctuation are painted with the
+// "key", "string", "number", "bool", "null" and "punctuation" roles of the
+// theme.  The data isn't validated, unexpected bytes are left plain.
+func (t Theme) HighlightJSON(data []byte) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == '"':
+			end := jsonStringEnd(data, i)
+			role := "string"
+			if isJSONKey(data, end) {
+				role = "key"
+			}
+			buf.WriteString(t.paint(role, string(data[i:end])))
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(data) && isJSONNumberByte(data[end]) {
+				end++
+			}
+			buf.WriteString(t.paint("number", string(data[i:end])))
+			i = end
+		case bytes.HasPrefix(data[i:], []byte("true")):
+			buf.WriteString(t.paint("bool", "true"))
+			i += len("true")
+		case bytes.HasPrefix(data[i:], []byte("false")):
+			buf.WriteString(t.paint("bool", "false"))
+			i += len("false")
+		case bytes.HasPrefix(data[i:], []byte("null")):
+			buf.WriteString(t.paint("null", "null"))
+			i += len("null")
+		case bytes.IndexByte([]byte("{}[],:"), c) >= 0:
+			buf.WriteString(t.paint("punctuation", string(c)))
+			i++
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+	return buf.String()
+}
+
+// jsonStringEnd gives the index right after the string starting at i.
+func jsonStringEnd(data []byte, i int) int {
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1
+		}
+	}
+	return len(data)
+}
+
+// isJSONKey tells if the string ending at i is an object key, followed by
+// a colon.
+func isJSONKey(data []byte, i int) bool {
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+		case ':':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func isJSONNumberByte(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-'
+}
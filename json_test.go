@@ -0,0 +1,60 @@
+package color
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightJSON(t *testing.T) {
+	data := `{
+  "name": "color",
+  "stars": 42,
+  "tags": ["go", "ansi"],
+  "fork": false,
+  "parent": null,
+  "quote": "say \"hi\""
+}`
+	got := HighlightJSON([]byte(data))
+
+	theme := DefaultTheme
+	for _, part := range []string{
+		theme["key"].Brush()(`"name"`),
+		theme["string"].Brush()(`"color"`),
+		theme["number"].Brush()("42"),
+		theme["string"].Brush()(`"ansi"`),
+		theme["bool"].Brush()("false"),
+		theme["null"].Brush()("null"),
+		theme["string"].Brush()(`"say \"hi\""`),
+		theme["punctuation"].Brush()("{"),
+	} {
+		if !strings.Contains(got, part) {
+			t.Errorf("Want %#v in %#v", part, got)
+		}
+	}
+
+	if theme["key"] == theme["string"] {
+		t.Errorf("Want keys and strings to have different styles")
+	}
+
+	if plain := visibleText(got); plain != data {
+		t.Errorf("Want formatting preserved, got %#v", plain)
+	}
+}
+
+func TestThemeHighlightJSON(t *testing.T) {
+	theme := Theme{"key": NewStyle("", RedPaint)}
+
+	want := "{" + Red(`"a"`) + `: "b"}`
+	if got := theme.HighlightJSON([]byte(`{"a": "b"}`)); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+// visibleText gives the text of s, without its escape sequences.
+func visibleText(s string) string {
+	var text string
+	for _, span := range Parse(s) {
+		text += span.Text
+	}
+	return text
+}
@@ -0,0 +1,179 @@
+package color
+
+import (
+	"fmt"
+)
+
+// Level is how many colors a terminal can display.
+type Level int
+
+// Color levels, from no colors at all to 24-bit truecolor.
+const (
+	LevelNone Level = iota
+	Level16
+	Level256
+	LevelTrueColor
+)
+
+// NearestPaint gives the one of the 16 standard paints closest to an RGB
+// color.
+func NearestPaint(r, g, b uint8) Paint {
+	return palette[nearest16(r, g, b)].p
+}
+
+// NearestNamed gives the name and the paint of the one of the 16 standard
+// colors closest to a hex color, such as "#ff8800".  The names are the ones
+// ParsePaint understands, i.e. "red" or "darkyellow".
+func NearestNamed(hex string) (name string, p Paint, err error) {
+	parsed, err := ParseHex(hex)
+	if err != nil {
+		return "", "", err
+	}
+	r, g, b, _ := parsed.RGB()
+	c := palette[nearest16(r, g, b)]
+	return c.name, c.p, nil
+}
+
+// nearest16 gives the index of the palette color closest to an RGB color.
+func nearest16(r, g, b uint8) int {
+	m := distanceMetric()
+	best, bestDist := 0, -1.0
+	for i, c := range palette {
+		if d := m.distance(r, g, b, c.r, c.g, c.b); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// Nearest256 gives the color of the xterm 256 colors palette closest to an
+// RGB color.  Only the color cube and the grays are considered, since the
+// first 16 colors are often changed by terminal themes.
+func Nearest256(r, g, b uint8) Paint {
+	m := distanceMetric()
+	best, bestDist := 16, -1.0
+	for i := 16; i < 256; i++ {
+		cr, cg, cb := rgb256(uint8(i))
+		if d := m.distance(r, g, b, cr, cg, cb); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return Index(uint8(best))
+}
+
+// downsample gives the closest paint that can be displayed at the level.
+func (p Paint) downsample(level Level) Paint {
+	if level >= LevelTrueColor || p == "" {
+		return p
+	}
+	if _, ok := p.index16(); ok {
+		return p
+	}
+
+	i, is256 := p.index256()
+	switch {
+	case is256 && level == Level256:
+		return p
+	case is256 && i < 16:
+		return palette[i].p
+	}
+
+	r, g, b, ok := p.RGB()
+	if !ok {
+		return p
+	}
+	if level == Level256 {
+		return Nearest256(r, g, b)
+	}
+	return NearestPaint(r, g, b)
+}
+
+// downsample gives the closest style that can be displayed at the level.
+func (s Style) downsample(level Level) Style {
+	bg, fg := s.bg.downsample(level), s.fg.downsample(level)
+	if bg == s.bg && fg == s.fg {
+		return s
+	}
+	d := Style{bg: bg, fg: fg, attrs: s.attrs, raw: s.raw}
+	d.code, d.csi = colorCodeAt(level, bg, fg, d.params())
+	return d
+}
+
+// basicOnly are the attributes terminals limited to 16 colors often don't
+// display.
+var basicOnly = []Attribute{Italic, Strikethrough, RapidBlink, CurlyUnderline, DottedUnderline, DashedUnderline}
+
+// Validate tells what the style loses on a terminal of the given level,
+// one warning per paint or attribute, so that programs can log how their
+// output degrades, i.e:
+//
+//	for _, warning := range style.Validate(color.ColorLevel()) {
+//		log.Printf("color: %s", warning)
+//	}
+//
+// Paints beyond the level are shown as the nearest color it has, and
+// terminals with 16 colors often lack the attributes added since, such as
+// italic.  Nothing is shown at LevelNone.  It gives no warning when the
+// style displays fully.
+func (s Style) Validate(level Level) []string {
+	if level == LevelNone {
+		if s.code == "" {
+			return nil
+		}
+		return []string{"nothing is painted without colors"}
+	}
+
+	var warnings []string
+	if p := s.bg.downsample(level); p != s.bg {
+		// the background is given in its background form, such as `43`
+		from, _ := s.bg.BackgroundCode()
+		to, _ := p.BackgroundCode()
+		warnings = append(warnings, fmt.Sprintf("background %s is shown as %s with %s", from, to, levelName(level)))
+	}
+	if p := s.fg.downsample(level); p != s.fg {
+		warnings = append(warnings, fmt.Sprintf("foreground %s is shown as %s with %s", s.fg, p, levelName(level)))
+	}
+	if level == Level16 {
+		attrs := splitAttributes(s.attrs)
+		for _, a := range basicOnly {
+			if hasAttribute(attrs, a) {
+				warnings = append(warnings, fmt.Sprintf("%s may not be shown with %s", a, levelName(level)))
+			}
+		}
+	}
+	return warnings
+}
+
+func levelName(level Level) string {
+	switch level {
+	case Level16:
+		return "16 colors"
+	case Level256:
+		return "256 colors"
+	case LevelTrueColor:
+		return "truecolor"
+	}
+	return "no colors"
+}
+
+// BrushAtLevel gives you a Brush painting with the style as a terminal of
+// the given level can display it: paints beyond the level are replaced by
+// the nearest color it has, with 16 colors the attributes Validate warns
+// about are dropped, and nothing is painted at LevelNone.  One style can
+// then be defined once for every terminal, i.e:
+//
+//	brush := accent.BrushAtLevel(level)
+func (s Style) BrushAtLevel(level Level) Brush {
+	if level == LevelNone {
+		return func(text string) string { return text }
+	}
+
+	attrs := s.attrs
+	if level == Level16 {
+		attrs = removeAttributes(attrs, basicOnly...)
+	}
+	bg, fg := s.bg.downsample(level), s.fg.downsample(level)
+	d := Style{bg: bg, fg: fg, attrs: attrs, raw: s.raw}
+	d.code, d.csi = colorCodeAt(level, bg, fg, joinParams(attrs, s.raw))
+	return d.Brush()
+}
@@ -0,0 +1,149 @@
+package color
+
+import (
+	"reflect"
+	"testing"
+)
+
+var nearestTT = []struct {
+	r, g, b uint8
+	want    Paint
+}{
+	{250, 10, 10, RedPaint},
+	{190, 10, 10, DarkRedPaint},
+	{0, 0, 0, BlackPaint},
+	{255, 255, 250, WhitePaint},
+	{120, 120, 130, DarkGrayPaint},
+	{10, 240, 250, CyanPaint},
+}
+
+func TestNearestPaint(t *testing.T) {
+	for _, test := range nearestTT {
+		if got := NearestPaint(test.r, test.g, test.b); got != test.want {
+			t.Errorf("(%d, %d, %d): Want %#v, got %#v", test.r, test.g, test.b, test.want, got)
+		}
+	}
+}
+
+func TestNearestNamed(t *testing.T) {
+	name, p, err := NearestNamed("#f01010")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "red" || p != RedPaint {
+		t.Errorf("Want %#v, got %#v", []interface{}{"red", RedPaint}, []interface{}{name, p})
+	}
+
+	name, p, err = NearestNamed("1010c0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "darkblue" || p != DarkBluePaint {
+		t.Errorf("Want %#v, got %#v", []interface{}{"darkblue", DarkBluePaint}, []interface{}{name, p})
+	}
+
+	if _, _, err := NearestNamed("#nothex"); err == nil {
+		t.Errorf("Want an error for an invalid hex color")
+	}
+}
+
+var nearest256TT = []struct {
+	r, g, b uint8
+	want    Paint
+}{
+	{255, 0, 0, Cube(5, 0, 0)},
+	{95, 135, 175, Cube(1, 2, 3)},
+	{100, 130, 170, Cube(1, 2, 3)},
+	{128, 128, 128, Gray(12)},
+	{0, 0, 0, Cube(0, 0, 0)},
+}
+
+func TestNearest256(t *testing.T) {
+	for _, test := range nearest256TT {
+		if got := Nearest256(test.r, test.g, test.b); got != test.want {
+			t.Errorf("(%d, %d, %d): Want %#v, got %#v", test.r, test.g, test.b, test.want, got)
+		}
+	}
+}
+
+var downsampleTT = []struct {
+	p     Paint
+	level Level
+	want  Paint
+}{
+	{PaintRGB(250, 10, 10), LevelTrueColor, PaintRGB(250, 10, 10)},
+	{PaintRGB(250, 10, 10), Level256, Cube(5, 0, 0)},
+	{PaintRGB(250, 10, 10), Level16, RedPaint},
+	{Cube(5, 0, 0), Level256, Cube(5, 0, 0)},
+	{Cube(5, 0, 0), Level16, RedPaint},
+	{Index(4), Level16, DarkBluePaint},
+	{DarkBluePaint, Level16, DarkBluePaint},
+	{"", Level16, ""},
+}
+
+func TestDownsample(t *testing.T) {
+	for _, test := range downsampleTT {
+		if got := test.p.downsample(test.level); got != test.want {
+			t.Errorf("%#v at level %d: Want %#v, got %#v", test.p, test.level, test.want, got)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	rgb := NewStyle("", PaintRGB(250, 10, 10))
+	if got := rgb.Validate(LevelTrueColor); len(got) != 0 {
+		t.Errorf("Want no warnings at truecolor, got %#v", got)
+	}
+	want := []string{"foreground 38;2;250;10;10 is shown as 1;31 with 16 colors"}
+	if got := rgb.Validate(Level16); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	style := NewStyle(Index(208), RedPaint).WithAttributes(Bold, Italic, CurlyUnderline)
+	if got := style.Validate(Level256); len(got) != 0 {
+		t.Errorf("Want no warnings at 256 colors, got %#v", got)
+	}
+	want = []string{
+		"background 48;5;208 is shown as 43 with 16 colors",
+		"italic may not be shown with 16 colors",
+		"curly underline may not be shown with 16 colors",
+	}
+	if got := style.Validate(Level16); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	if got := NewStyle("", RedPaint).Validate(LevelNone); len(got) != 1 {
+		t.Errorf("Want a warning without colors, got %#v", got)
+	}
+	if got := (Style{}).Validate(LevelNone); len(got) != 0 {
+		t.Errorf("Want no warnings for the empty style, got %#v", got)
+	}
+}
+
+func TestBrushAtLevel(t *testing.T) {
+	style := NewStyle("", PaintRGB(250, 10, 10)).WithAttributes(Bold, Italic)
+
+	for _, test := range []struct {
+		level Level
+		want  string
+	}{
+		{LevelNone, "x"},
+		{Level16, "\033[1;31mx\033[0m"},
+		{Level256, "\033[38;5;196;1;3mx\033[0m"},
+		{LevelTrueColor, "\033[38;2;250;10;10;1;3mx\033[0m"},
+	} {
+		if got := style.BrushAtLevel(test.level)("x"); got != test.want {
+			t.Errorf("level %d: Want %#v, got %#v", test.level, test.want, got)
+		}
+	}
+}
+
+func TestBrushAtLevelIgnoresColorLevel(t *testing.T) {
+	defer SetColorLevel(ColorLevel())
+	SetColorLevel(Level16)
+
+	brush := NewStyle("", PaintRGB(255, 135, 0)).BrushAtLevel(Level256)
+	if want, got := "\033[38;5;208mx\033[0m", brush("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
@@ -0,0 +1,92 @@
+package color
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// KeyValueOptions are the brushes used to paint logfmt style key=value
+// pairs.  A nil Brush leaves its part plain.
+type KeyValueOptions struct {
+	Key   Brush
+	Value Brush
+}
+
+// DefaultKeyValueOptions paints keys in cyan and values in light gray.  It
+// is used by KeyValue.
+var DefaultKeyValueOptions = KeyValueOptions{
+	Key:   Cyan,
+	Value: LightGray,
+}
+
+// KeyValue gives you a logfmt style `key=value` pair painted according to
+// DefaultKeyValueOptions, i.e:
+//
+//	fmt.Println(color.KeyValue("status", "ok"), color.KeyValue("took", "3ms"))
+func KeyValue(key, value string) string {
+	return DefaultKeyValueOptions.KeyValue(key, value)
+}
+
+// KeyValue gives you a `key=value` pair with the key and the value painted
+// separately.  The `=` is left plain, and so is an empty value.
+func (o KeyValueOptions) KeyValue(key, value string) string {
+	if value == "" {
+		return o.Key.paint(key) + "="
+	}
+	return o.Key.paint(key) + "=" + o.Value.paint(value)
+}
+
+// KeyValueBlock lays out pairs as `key: value` lines, with the values
+// aligned after the longest key, as in the output of describe commands:
+//
+//	Name:    web-1
+//	Status:  running
+//
+// Keys are measured by their DisplayWidth, so they can already be colored
+// and hold wide East Asian characters.  Each line ends with a newline.
+func KeyValueBlock(pairs [][2]string, keyStyle, valStyle Style) string {
+	width := 0
+	for _, pair := range pairs {
+		if n := DisplayWidth(pair[0]); n > width {
+			width = n
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, pair := range pairs {
+		key, value := pair[0], pair[1]
+		buf.WriteString(keyStyle.colorize(key) + ":")
+		buf.WriteString(strings.Repeat(" ", width-DisplayWidth(key)+2))
+		buf.WriteString(valStyle.colorize(value) + "\n")
+	}
+	return buf.String()
+}
+
+// levelToken matches the log level names colored by ColorizeLevel.
+var levelToken = regexp.MustCompile(`(?i)\b(debug|info|warn|warning|error|fatal)\b`)
+
+// ColorizeLevel paints the first log level found in a line, such as `INFO`
+// or `error`, with the role of the same name in DefaultTheme.  It's meant
+// for filters colorizing existing logs, i.e:
+//
+//	tail -f app.log | colorize
+func ColorizeLevel(line string) string {
+	return DefaultTheme.ColorizeLevel(line)
+}
+
+// ColorizeLevel paints the first log level found in a line with the role of
+// the same name: "debug", "info", "warn", "error" or "fatal".  A `WARNING`
+// is painted as "warn".  Lines without a level are left as they are.
+func (t Theme) ColorizeLevel(line string) string {
+	m := levelToken.FindStringIndex(line)
+	if m == nil {
+		return line
+	}
+	token := line[m[0]:m[1]]
+	role := strings.ToLower(token)
+	if role == "warning" {
+		role = "warn"
+	}
+	return line[:m[0]] + t.paint(role, token) + line[m[1]:]
+}
@@ -0,0 +1,93 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestKeyValue(t *testing.T) {
+	want := Cyan("status") + "=" + LightGray("ok")
+	if got := KeyValue("status", "ok"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	spans := Parse(KeyValue("status", "ok"))
+	if len(spans) != 3 {
+		t.Fatalf("Want 3 spans, got %#v", spans)
+	}
+	if spans[0].Style == spans[2].Style {
+		t.Errorf("Want key and value to have different styles, got %#v", spans)
+	}
+	if spans[1] != (Span{Style{}, "="}) {
+		t.Errorf("Want a plain =, got %#v", spans[1])
+	}
+}
+
+func TestKeyValueEmpty(t *testing.T) {
+	want := Cyan("status") + "="
+	if got := KeyValue("status", ""); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestKeyValueOptions(t *testing.T) {
+	opts := KeyValueOptions{Key: Blue}
+
+	want := Blue("status") + "=ok"
+	if got := opts.KeyValue("status", "ok"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+var colorizeLevelTT = []struct {
+	line, want string
+}{
+	{"12:00 DEBUG cache warmed", "12:00 " + NewStyle("", DarkGrayPaint).Brush()("DEBUG") + " cache warmed"},
+	{"12:00 info listening", "12:00 " + NewStyle("", GreenPaint).Brush()("info") + " listening"},
+	{"[Warn] disk 91% full", "[" + NewStyle("", YellowPaint).Brush()("Warn") + "] disk 91% full"},
+	{"WARNING: deprecated", NewStyle("", YellowPaint).Brush()("WARNING") + ": deprecated"},
+	{"level=error msg=boom error", "level=" + NewStyle("", RedPaint).Brush()("error") + " msg=boom error"},
+	{"FATAL out of memory", NewStyle("", RedPaint).WithAttributes(Bold, Reverse).Brush()("FATAL") + " out of memory"},
+	{"informational errors", "informational errors"},
+	{"request served in 3ms", "request served in 3ms"},
+}
+
+func TestColorizeLevel(t *testing.T) {
+	for _, test := range colorizeLevelTT {
+		if got := ColorizeLevel(test.line); got != test.want {
+			t.Errorf("%q: Want %#v, got %#v", test.line, test.want, got)
+		}
+	}
+}
+
+func TestKeyValueBlock(t *testing.T) {
+	keyStyle, valStyle := NewStyle("", CyanPaint), NewStyle("", LightGrayPaint)
+	key, val := keyStyle.Brush(), valStyle.Brush()
+
+	got := KeyValueBlock([][2]string{
+		{"Name", "web-1"},
+		{"IP", "10.0.0.7"},
+		{Bold.code() + "Status", "running"},
+	}, keyStyle, valStyle)
+
+	want := key("Name") + ":    " + val("web-1") + "\n" +
+		key("IP") + ":      " + val("10.0.0.7") + "\n" +
+		key(Bold.code()+"Status") + ":  " + val("running") + "\n"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// wide keys take two cells a rune
+	got = KeyValueBlock([][2]string{{"名前", "web-1"}, {"IP", "10.0.0.7"}}, keyStyle, valStyle)
+	want = key("名前") + ":  " + val("web-1") + "\n" +
+		key("IP") + ":    " + val("10.0.0.7") + "\n"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	Disable()
+	defer Enable()
+	want = "Name:  web-1\nIP:    10.0.0.7\n"
+	if got := KeyValueBlock([][2]string{{"Name", "web-1"}, {"IP", "10.0.0.7"}}, keyStyle, valStyle); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
@@ -0,0 +1,86 @@
+package color
+
+import (
+	"bytes"
+	"strings"
+)
+
+// MarkdownInline renders the inline emphasis of markdown, `**bold**`,
+// `*italic*`, `~~strike~~` and backquoted code, with the attributes they
+// stand for and the "code" role of DefaultTheme, i.e:
+//
+//	fmt.Println(color.MarkdownInline("run `make` **before** pushing"))
+func MarkdownInline(s string) string {
+	return DefaultTheme.MarkdownInline(s)
+}
+
+// MarkdownInline renders the inline emphasis of markdown, painting code
+// spans with the role "code".  Emphasis can nest, but code spans are
+// verbatim.  The markers are removed, except for the ones without a closing
+// marker, which are left as they are.
+func (t Theme) MarkdownInline(s string) string {
+	var out, text bytes.Buffer
+	var bold, italic, strike bool
+
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+		var attrs []Attribute
+		if bold {
+			attrs = append(attrs, Bold)
+		}
+		if italic {
+			attrs = append(attrs, Italic)
+		}
+		if strike {
+			attrs = append(attrs, Strikethrough)
+		}
+		out.WriteString(Style{}.WithAttributes(attrs...).colorize(text.String()))
+		text.Reset()
+	}
+	toggle := func(open *bool, marker string, rest string) bool {
+		if !*open && !strings.Contains(rest, marker) {
+			return false
+		}
+		flush()
+		*open = !*open
+		return true
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		switch {
+		case rest[0] == '`':
+			end := strings.IndexByte(rest[1:], '`')
+			if end < 0 {
+				break
+			}
+			flush()
+			out.WriteString(t.paint("code", rest[1:1+end]))
+			i += end + 2
+			continue
+		case strings.HasPrefix(rest, "**"):
+			if !toggle(&bold, "**", rest[2:]) {
+				text.WriteString("**")
+			}
+			i += 2
+			continue
+		case strings.HasPrefix(rest, "~~"):
+			if !toggle(&strike, "~~", rest[2:]) {
+				text.WriteString("~~")
+			}
+			i += 2
+			continue
+		case rest[0] == '*':
+			if toggle(&italic, "*", rest[1:]) {
+				i++
+				continue
+			}
+		}
+		text.WriteByte(s[i])
+		i++
+	}
+	flush()
+	return out.String()
+}
@@ -0,0 +1,39 @@
+package color
+
+import (
+	"testing"
+)
+
+var markdownTT = []struct {
+	s, want string
+}{
+	{"plain text", "plain text"},
+	{"a **bold** word", "a " + Style{}.WithAttributes(Bold).Brush()("bold") + " word"},
+	{"an *italic* word", "an " + Style{}.WithAttributes(Italic).Brush()("italic") + " word"},
+	{"a ~~struck~~ word", "a " + Style{}.WithAttributes(Strikethrough).Brush()("struck") + " word"},
+	{"run `make test`", "run " + NewStyle("", DarkCyanPaint).Brush()("make test")},
+	{"**bold *both* bold**",
+		Style{}.WithAttributes(Bold).Brush()("bold ") +
+			Style{}.WithAttributes(Bold, Italic).Brush()("both") +
+			Style{}.WithAttributes(Bold).Brush()(" bold")},
+	{"`**not bold**`", NewStyle("", DarkCyanPaint).Brush()("**not bold**")},
+	{"2 * 3 = 6", "2 * 3 = 6"},
+	{"**unclosed and `open", "**unclosed and `open"},
+	{"~~a", "~~a"},
+}
+
+func TestMarkdownInline(t *testing.T) {
+	for _, test := range markdownTT {
+		if got := MarkdownInline(test.s); got != test.want {
+			t.Errorf("%q: Want %#v, got %#v", test.s, test.want, got)
+		}
+	}
+}
+
+func TestMarkdownInlineDisabled(t *testing.T) {
+	Disable()
+	defer Enable()
+	if want, got := "a bold and code word", MarkdownInline("a **bold** and `code` word"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
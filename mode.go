@@ -0,0 +1,25 @@
+package color
+
+// BeginSync gives the sequence starting a synchronized update, DEC private
+// mode 2026: the terminal holds what follows until EndSync, and then draws
+// it at once, for flicker-free redraws, i.e:
+//
+//	fmt.Print(color.BeginSync(), frame, color.EndSync())
+//
+// Terminals without the mode ignore it.  It is empty when colors are
+// disabled, since the output then likely isn't a terminal.
+func BeginSync() string {
+	if !Enabled() {
+		return ""
+	}
+	return csi("?2026", 'h')
+}
+
+// EndSync gives the sequence ending a synchronized update started with
+// BeginSync.  It is empty when colors are disabled.
+func EndSync() string {
+	if !Enabled() {
+		return ""
+	}
+	return csi("?2026", 'l')
+}
@@ -0,0 +1,20 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestSync(t *testing.T) {
+	if want, got := "\033[?2026h", BeginSync(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := "\033[?2026l", EndSync(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	WithDisabled(func() {
+		if got := BeginSync() + EndSync(); got != "" {
+			t.Errorf("Want no sequences when disabled, got %#v", got)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package color
+
+import (
+	"fmt"
+)
+
+// st is the string terminator ending OSC sequences.
+const st = "\033\\"
+
+// osc gives the operating system command sequence with the parameters.
+func osc(params string) string {
+	return "\033]" + params + st
+}
+
+// SetPaletteColor gives the OSC 4 sequence changing the color the terminal
+// shows for a palette index, i.e. making DarkRedPaint, index 1, a softer
+// red:
+//
+//	fmt.Print(color.SetPaletteColor(1, 0xcc, 0x44, 0x44))
+//
+// The change lasts until ResetPaletteColor or ResetPalette is printed, or
+// the terminal is reset.  It is empty when colors are disabled.
+func SetPaletteColor(index uint8, r, g, b uint8) string {
+	if !Enabled() {
+		return ""
+	}
+	return osc(fmt.Sprintf("4;%d;rgb:%02x/%02x/%02x", index, r, g, b))
+}
+
+// ResetPaletteColor gives the OSC 104 sequence restoring the terminal's own
+// color for a palette index.  It is empty when colors are disabled.
+func ResetPaletteColor(index uint8) string {
+	if !Enabled() {
+		return ""
+	}
+	return osc(fmt.Sprintf("104;%d", index))
+}
+
+// ResetPalette gives the OSC 104 sequence restoring all the terminal's own
+// palette colors.  It is empty when colors are disabled.
+func ResetPalette() string {
+	if !Enabled() {
+		return ""
+	}
+	return osc("104")
+}
+
+// SetDefaultForeground gives the OSC 10 sequence changing the color of text
+// printed without a foreground color.  It is empty when colors are
+// disabled.
+func SetDefaultForeground(r, g, b uint8) string {
+	return setDefaultColor(10, r, g, b)
+}
+
+// SetDefaultBackground gives the OSC 11 sequence changing the background
+// of the terminal.  It is empty when colors are disabled.
+func SetDefaultBackground(r, g, b uint8) string {
+	return setDefaultColor(11, r, g, b)
+}
+
+// QueryDefaultForeground gives the OSC 10 sequence asking the terminal for
+// its default foreground color.  Terminals supporting it answer on their
+// input with the same sequence holding the color, such as
+// `\033]10;rgb:ffff/ffff/ffff\033\`, which is up to you to read.  It is
+// empty when colors are disabled.
+func QueryDefaultForeground() string {
+	return queryDefaultColor(10)
+}
+
+// QueryDefaultBackground gives the OSC 11 sequence asking the terminal for
+// its background color, to tell a light theme from a dark one.  It is
+// answered like QueryDefaultForeground, and empty when colors are disabled.
+func QueryDefaultBackground() string {
+	return queryDefaultColor(11)
+}
+
+func setDefaultColor(code int, r, g, b uint8) string {
+	if !Enabled() {
+		return ""
+	}
+	return osc(fmt.Sprintf("%d;rgb:%02x/%02x/%02x", code, r, g, b))
+}
+
+func queryDefaultColor(code int) string {
+	if !Enabled() {
+		return ""
+	}
+	return osc(fmt.Sprintf("%d;?", code))
+}
@@ -0,0 +1,51 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestSetPaletteColor(t *testing.T) {
+	want := "\033]4;1;rgb:cc/44/0a\033\\"
+	if got := SetPaletteColor(1, 0xcc, 0x44, 0x0a); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestResetPalette(t *testing.T) {
+	want := "\033]104;12\033\\"
+	if got := ResetPaletteColor(12); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want = "\033]104\033\\"
+	if got := ResetPalette(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestDefaultColors(t *testing.T) {
+	for _, test := range []struct {
+		got, want string
+	}{
+		{SetDefaultForeground(0xee, 0xee, 0xec), "\033]10;rgb:ee/ee/ec\033\\"},
+		{SetDefaultBackground(0x1e, 0x1e, 0x2e), "\033]11;rgb:1e/1e/2e\033\\"},
+		{QueryDefaultForeground(), "\033]10;?\033\\"},
+		{QueryDefaultBackground(), "\033]11;?\033\\"},
+	} {
+		if test.got != test.want {
+			t.Errorf("Want %#v, got %#v", test.want, test.got)
+		}
+	}
+}
+
+func TestPaletteDisabled(t *testing.T) {
+	Disable()
+	defer Enable()
+
+	for _, got := range []string{SetPaletteColor(1, 0, 0, 0), ResetPaletteColor(1), ResetPalette(),
+		SetDefaultForeground(0, 0, 0), SetDefaultBackground(0, 0, 0), QueryDefaultForeground(), QueryDefaultBackground()} {
+		if got != "" {
+			t.Errorf("Want no sequence when disabled, got %#v", got)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package color
+
+import (
+	"strconv"
+	"strings"
+)
+
+// palette holds the 16 standard paints along with the RGB value xterm uses
+// for each of them, indexed by their ANSI color number.
+var palette = [16]struct {
+	name    string
+	p       Paint
+	r, g, b uint8
+}{
+	{"black", BlackPaint, 0, 0, 0},
+	{"darkred", DarkRedPaint, 205, 0, 0},
+	{"darkgreen", DarkGreenPaint, 0, 205, 0},
+	{"darkyellow", DarkYellowPaint, 205, 205, 0},
+	{"darkblue", DarkBluePaint, 0, 0, 238},
+	{"darkpurple", DarkPurplePaint, 205, 0, 205},
+	{"darkcyan", DarkCyanPaint, 0, 205, 205},
+	{"lightgray", LightGrayPaint, 229, 229, 229},
+	{"darkgray", DarkGrayPaint, 127, 127, 127},
+	{"red", RedPaint, 255, 0, 0},
+	{"green", GreenPaint, 0, 255, 0},
+	{"yellow", YellowPaint, 255, 255, 0},
+	{"blue", BluePaint, 92, 92, 255},
+	{"purple", PurplePaint, 255, 0, 255},
+	{"cyan", CyanPaint, 0, 255, 255},
+	{"white", WhitePaint, 255, 255, 255},
+}
+
+// index16 gives the ANSI color number, from 0 to 15, of one of the 16
+// standard paints, whether it's in the `0;3x`/`1;3x` form of the constants
+// or in the `3x`/`9x` form.
+func (p Paint) index16() (int, bool) {
+	s := string(p)
+	bright := false
+	switch {
+	case len(s) == 4 && s[:3] == "0;3":
+	case len(s) == 4 && s[:3] == "1;3":
+		bright = true
+	case len(s) == 2 && s[0] == '3':
+	case len(s) == 2 && s[0] == '9':
+		bright = true
+	default:
+		return 0, false
+	}
+
+	n := int(s[len(s)-1] - '0')
+	if n < 0 || n > 7 {
+		return 0, false
+	}
+	if bright {
+		n += 8
+	}
+	return n, true
+}
+
+// Index gives you the paint of a color of the xterm 256 colors palette, by
+// its index: the 16 standard colors first, then the 6x6x6 color cube and
+// finally 24 shades of gray.
+func Index(i uint8) Paint {
+	return Paint("38;5;" + strconv.Itoa(int(i)))
+}
+
+// Color256 is Index, under the name other color packages give it.
+func Color256(n uint8) Paint {
+	return Index(n)
+}
+
+// Cube gives you the paint of a color of the 6x6x6 color cube of the xterm
+// 256 colors palette.  Each component goes from 0 to 5, larger values are
+// clamped to 5.
+func Cube(r, g, b uint8) Paint {
+	return Index(16 + 36*min5(r) + 6*min5(g) + min5(b))
+}
+
+// Gray gives you the paint of one of the 24 shades of gray of the xterm 256
+// colors palette, from 0 for the darkest to 23 for the lightest.  Larger
+// values are clamped to 23.
+func Gray(level uint8) Paint {
+	if level > 23 {
+		level = 23
+	}
+	return Index(232 + level)
+}
+
+func min5(c uint8) uint8 {
+	if c > 5 {
+		return 5
+	}
+	return c
+}
+
+// cubeLevels are the values of the components of the color cube.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// index256 gives the index of a 256 colors paint.
+func (p Paint) index256() (uint8, bool) {
+	s := string(p)
+	if !strings.HasPrefix(s, "38;5;") {
+		return 0, false
+	}
+	i, err := strconv.ParseUint(s[len("38;5;"):], 10, 8)
+	if err != nil {
+		return 0, false
+	}
+	return uint8(i), true
+}
+
+// rgb256 gives the RGB value xterm uses for a color of the 256 colors
+// palette.
+func rgb256(i uint8) (r, g, b uint8) {
+	switch {
+	case i < 16:
+		c := palette[i]
+		return c.r, c.g, c.b
+	case i < 232:
+		i -= 16
+		return cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]
+	default:
+		v := 8 + 10*(i-232)
+		return v, v, v
+	}
+}
+
+// Valid tells if the paint is one this package understands: NoPaint, the
+// default foreground `39`, one of the 16 standard colors in any of their
+// forms, or a 256 colors or truecolor paint with components that fit in a
+// byte.  Use it to check paints coming from users.
+func (p Paint) Valid() bool {
+	if p == "" || p == "39" {
+		return true
+	}
+	_, _, _, ok := p.RGB()
+	return ok
+}
+
+// Normalize gives the canonical form of a paint, so that paints naming the
+// same color compare equal however they were written.  The standard colors
+// are given as the paint constants of this package, so both `91` and
+// `1;31` give RedPaint.  Paints that aren't understood are left unchanged.
+func (p Paint) Normalize() Paint {
+	if i, ok := p.index16(); ok {
+		return palette[i].p
+	}
+	if i, ok := p.index256(); ok {
+		return Index(i)
+	}
+	if strings.HasPrefix(string(p), "38;2;") {
+		if r, g, b, ok := p.RGB(); ok {
+			return PaintRGB(r, g, b)
+		}
+	}
+	return p
+}
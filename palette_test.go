@@ -0,0 +1,106 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestIndex(t *testing.T) {
+	if got, want := Index(196), Paint("38;5;196"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if got, want := Index(196), Cube(5, 0, 0); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestColor256(t *testing.T) {
+	if got, want := Color256(196), Index(196); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want := "\033[38;5;196m" + "\033[48;5;17m" + "text" + "\033[0m"
+	if got := NewStyle(Color256(17), Color256(196)).Brush()("text"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+var palette256TT = []struct {
+	name string
+	p    Paint
+	want Paint
+}{
+	{"cube origin", Cube(0, 0, 0), Index(16)},
+	{"cube corner", Cube(5, 5, 5), Index(231)},
+	{"cube clamped", Cube(9, 9, 9), Index(231)},
+	{"darkest gray", Gray(0), Index(232)},
+	{"lightest gray", Gray(23), Index(255)},
+	{"gray clamped", Gray(99), Index(255)},
+}
+
+func TestPalette256(t *testing.T) {
+	for _, test := range palette256TT {
+		if test.p != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, test.p)
+		}
+	}
+}
+
+var rgb256TT = []struct {
+	p       Paint
+	r, g, b uint8
+}{
+	{Index(1), 205, 0, 0},
+	{Index(196), 255, 0, 0},
+	{Cube(1, 2, 3), 95, 135, 175},
+	{Gray(0), 8, 8, 8},
+	{Gray(23), 238, 238, 238},
+}
+
+func TestRGB256(t *testing.T) {
+	for _, test := range rgb256TT {
+		r, g, b, ok := test.p.RGB()
+		if !ok || r != test.r || g != test.g || b != test.b {
+			t.Errorf("%#v: want (%d, %d, %d), got (%d, %d, %d, %v)", test.p, test.r, test.g, test.b, r, g, b, ok)
+		}
+	}
+}
+
+var normalizeTT = []struct {
+	p    Paint
+	want Paint
+}{
+	{"90", DarkGrayPaint},
+	{"1;30", DarkGrayPaint},
+	{"31", DarkRedPaint},
+	{"0;31", DarkRedPaint},
+	{"97", WhitePaint},
+	{"38;5;007", Index(7)},
+	{"38;2;010;0;255", PaintRGB(10, 0, 255)},
+	{"", ""},
+	{"bogus", "bogus"},
+}
+
+func TestNormalize(t *testing.T) {
+	for _, test := range normalizeTT {
+		if got := test.p.Normalize(); got != test.want {
+			t.Errorf("%#v: Want %#v, got %#v", test.p, test.want, got)
+		}
+	}
+
+	if Paint("90").Normalize() != Paint("1;30").Normalize() {
+		t.Errorf("Want both bright black forms to normalize equal")
+	}
+}
+
+func TestPaintValid(t *testing.T) {
+	for _, p := range []Paint{NoPaint, "39", RedPaint, DarkBluePaint, "34", "97", Index(0), Index(255), PaintRGB(1, 2, 3)} {
+		if !p.Valid() {
+			t.Errorf("%#v: Want valid", p)
+		}
+	}
+	for _, p := range []Paint{"red", "1;38", "38;5;256", "38;2;1;2", "38;2;1;2;300", "0;31;1"} {
+		if p.Valid() {
+			t.Errorf("%#v: Want invalid", p)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package color
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// RainbowOptions are the styles used to paint brackets by nesting depth.
+type RainbowOptions struct {
+	// Depths are cycled through as brackets nest, the outermost pair
+	// gets the first one.
+	Depths []Style
+	// Unmatched paints the brackets without a pair.
+	Unmatched Style
+}
+
+// DefaultRainbowOptions cycles through yellow, purple and cyan, and shows
+// unmatched brackets in white on red.  It is used by RainbowParens.
+var DefaultRainbowOptions = RainbowOptions{
+	Depths: []Style{
+		NewStyle("", YellowPaint),
+		NewStyle("", PurplePaint),
+		NewStyle("", CyanPaint),
+	},
+	Unmatched: NewStyle(DarkRedPaint, WhitePaint),
+}
+
+// RainbowParens paints the matching `()`, `[]` and `{}` pairs of s by how
+// deeply they nest, according to DefaultRainbowOptions, i.e:
+//
+//	fmt.Println(color.RainbowParens("(map (fn [x] {x 1}) xs)"))
+func RainbowParens(s string) string {
+	return DefaultRainbowOptions.RainbowParens(s)
+}
+
+// RainbowParens paints the matching bracket pairs of s by nesting depth,
+// and the brackets without a pair with Unmatched.  Escape sequences already
+// in s are left alone.
+func (o RainbowOptions) RainbowParens(s string) string {
+	if !Enabled() {
+		return s
+	}
+
+	depths := bracketDepths(s)
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		depth, ok := depths[i]
+		switch {
+		case !ok:
+			buf.WriteByte(s[i])
+		case depth < 0:
+			buf.WriteString(o.Unmatched.colorize(s[i : i+1]))
+		case len(o.Depths) == 0:
+			buf.WriteByte(s[i])
+		default:
+			buf.WriteString(o.Depths[depth%len(o.Depths)].colorize(s[i : i+1]))
+		}
+	}
+	return buf.String()
+}
+
+// closing gives the closing bracket of each opening one.
+var closing = map[byte]byte{'(': ')', '[': ']', '{': '}'}
+
+// bracketDepths gives the nesting depth of each bracket of s by its index,
+// from 0 for the outermost pairs, and -1 for brackets without a pair.  The
+// brackets of escape sequences are skipped.
+func bracketDepths(s string) map[int]int {
+	depths := make(map[int]int)
+	var open []int
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case esc, csi8:
+			if n, ok := escapeLen([]byte(s[i:])); ok {
+				i += n - 1
+			}
+		default:
+			if c >= utf8.RuneSelf {
+				// skip the rest of the rune, so its bytes aren't
+				// taken for an introducer
+				_, size := utf8.DecodeRuneInString(s[i:])
+				i += size - 1
+			}
+		case '(', '[', '{':
+			open = append(open, i)
+		case ')', ']', '}':
+			if len(open) == 0 || closing[s[open[len(open)-1]]] != c {
+				depths[i] = -1
+				continue
+			}
+			depth := len(open) - 1
+			depths[open[depth]], depths[i] = depth, depth
+			open = open[:depth]
+		}
+	}
+	for _, i := range open {
+		depths[i] = -1
+	}
+	return depths
+}
+
+// DepthColorize paints the pairs of open and close delimiters of s by how
+// deeply they nest, cycling through the palette, the outermost pairs
+// getting its first paint.  Delimiters without a pair are painted with the
+// Unmatched style of DefaultRainbowOptions, i.e:
+//
+//	color.DepthColorize("<a <b> <c <d>>>", '<', '>', color.Categorical(4))
+//
+// When open and close are the same, such as quotes, the delimiters pair up
+// in turn and don't nest.  Escape sequences already in s are left alone.
+func DepthColorize(s string, open, close rune, palette []Paint) string {
+	if !Enabled() {
+		return s
+	}
+
+	depths := delimiterDepths(s, open, close)
+	var buf bytes.Buffer
+	pos := 0
+	eachToken(s, func(tok string, escape bool) {
+		defer func(n int) { pos += n }(len(tok))
+		if escape {
+			buf.WriteString(tok)
+			return
+		}
+		for i, r := range tok {
+			depth, ok := depths[pos+i]
+			switch {
+			case !ok || len(palette) == 0 && depth >= 0:
+				buf.WriteRune(r)
+			case depth < 0:
+				buf.WriteString(DefaultRainbowOptions.Unmatched.colorize(string(r)))
+			default:
+				buf.WriteString(NewStyle("", palette[depth%len(palette)]).colorize(string(r)))
+			}
+		}
+	})
+	return buf.String()
+}
+
+// delimiterDepths gives the nesting depth of each delimiter of s by its
+// index, like bracketDepths does for brackets.
+func delimiterDepths(s string, open, close rune) map[int]int {
+	depths := make(map[int]int)
+	var opened []int
+	pos := 0
+	eachToken(s, func(tok string, escape bool) {
+		defer func(n int) { pos += n }(len(tok))
+		if escape {
+			return
+		}
+		for i, r := range tok {
+			switch {
+			case r != open && r != close:
+			case r == open && (open != close || len(opened) == 0):
+				opened = append(opened, pos+i)
+			case len(opened) == 0:
+				depths[pos+i] = -1
+			default:
+				depth := len(opened) - 1
+				depths[opened[depth]], depths[pos+i] = depth, depth
+				opened = opened[:depth]
+			}
+		}
+	})
+	for _, i := range opened {
+		depths[i] = -1
+	}
+	return depths
+}
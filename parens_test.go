@@ -0,0 +1,64 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestRainbowParens(t *testing.T) {
+	d0 := DefaultRainbowOptions.Depths[0].Brush()
+	d1 := DefaultRainbowOptions.Depths[1].Brush()
+	d2 := DefaultRainbowOptions.Depths[2].Brush()
+	bad := DefaultRainbowOptions.Unmatched.Brush()
+
+	for _, test := range []struct {
+		s, want string
+	}{
+		{"f(x)", "f" + d0("(") + "x" + d0(")")},
+		{"(a)(b)", d0("(") + "a" + d0(")") + d0("(") + "b" + d0(")")},
+		{"([{(x)}])",
+			d0("(") + d1("[") + d2("{") + d0("(") + "x" + d0(")") + d2("}") + d1("]") + d0(")")},
+		{"(a]", bad("(") + "a" + bad("]")},
+		{"(a))", d0("(") + "a" + d0(")") + bad(")")},
+		{"((a)", bad("(") + d1("(") + "a" + d1(")")},
+		{"no brackets", "no brackets"},
+		{Red("x"), Red("x")},
+	} {
+		if got := RainbowParens(test.s); got != test.want {
+			t.Errorf("%q: Want %#v, got %#v", test.s, test.want, got)
+		}
+	}
+}
+
+func TestRainbowParensDisabled(t *testing.T) {
+	Disable()
+	defer Enable()
+	if got := RainbowParens("(a]"); got != "(a]" {
+		t.Errorf("Want %#v, got %#v", "(a]", got)
+	}
+}
+
+func TestDepthColorize(t *testing.T) {
+	palette := []Paint{RedPaint, GreenPaint, BluePaint}
+	d0 := NewStyle("", RedPaint).Brush()
+	d1 := NewStyle("", GreenPaint).Brush()
+	d2 := NewStyle("", BluePaint).Brush()
+	bad := DefaultRainbowOptions.Unmatched.Brush()
+
+	for _, test := range []struct {
+		s           string
+		open, close rune
+		want        string
+	}{
+		{"<a <b <c <d>>>>", '<', '>',
+			d0("<") + "a " + d1("<") + "b " + d2("<") + "c " + d0("<") + "d" + d0(">") + d2(">") + d1(">") + d0(">")},
+		{"«a» «b", '«', '»', d0("«") + "a" + d0("»") + " " + bad("«") + "b"},
+		{"a>b<c", '<', '>', "a" + bad(">") + "b" + bad("<") + "c"},
+		{`"a" "b" "c`, '"', '"', d0(`"`) + "a" + d0(`"`) + " " + d0(`"`) + "b" + d0(`"`) + " " + bad(`"`) + "c"},
+		{Red("a") + "<b>", '<', '>', Red("a") + d0("<") + "b" + d0(">")},
+		{"none", '<', '>', "none"},
+	} {
+		if got := DepthColorize(test.s, test.open, test.close, palette); got != test.want {
+			t.Errorf("%q: Want %#v, got %#v", test.s, test.want, got)
+		}
+	}
+}
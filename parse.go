@@ -0,0 +1,111 @@
+package color
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// aliases holds the paints named with DefineAlias, by normalized name.
+var aliases = struct {
+	sync.RWMutex
+	paints map[string]Paint
+}{paints: make(map[string]Paint)}
+
+// DefineAlias names a paint, so that ParsePaint, and the themes and
+// configurations relying on it, understand the name, i.e. for a brand
+// color:
+//
+//	color.DefineAlias("primary", color.PaintRGB(0x00, 0x7a, 0xcc))
+//	theme, err := color.LoadTheme(f) // `{"title": "primary"}` works
+//
+// Aliases are looked up before the standard names, so they can also
+// replace them.  Defining an alias again replaces its paint.
+func DefineAlias(name string, p Paint) {
+	aliases.Lock()
+	aliases.paints[normalizeName(name)] = p
+	aliases.Unlock()
+}
+
+// normalizeName gives the form of a color name that is compared: lower
+// case, without spaces, dashes and underscores.
+func normalizeName(s string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(s))
+}
+
+// ParsePaint gives you the paint described by s, either an alias defined
+// with DefineAlias, the name of one of the 16 standard paints, such as
+// "red" or "dark blue", or a hex color as understood by ParseHex.  Names
+// are case insensitive and spaces, dashes and underscores are ignored.
+func ParsePaint(s string) (Paint, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	name := normalizeName(s)
+	aliases.RLock()
+	p, ok := aliases.paints[name]
+	aliases.RUnlock()
+	if ok {
+		return p, nil
+	}
+	for _, c := range palette {
+		if c.name == name {
+			return c.p, nil
+		}
+	}
+
+	p, err := ParseHex(s)
+	if err != nil {
+		return "", fmt.Errorf("color: unknown color %q", s)
+	}
+	return p, nil
+}
+
+// ParseHex gives you the truecolor paint of a hex color, such as "#ff8800"
+// or "ff8800", or its short form "#f80".
+func ParseHex(s string) (Paint, error) {
+	hex := strings.TrimPrefix(s, "#")
+	switch len(hex) {
+	case 3:
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	case 6:
+	default:
+		return "", fmt.Errorf("color: invalid hex color %q, want 3 or 6 hex digits", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "", fmt.Errorf("color: invalid hex color %q, not a hex number", s)
+	}
+	return PaintRGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+}
+
+// MarshalText gives the name of the standard paints and the hex form of the
+// other ones, so that paints can be used in JSON and other text formats.
+func (p Paint) MarshalText() ([]byte, error) {
+	if p == "" {
+		return []byte{}, nil
+	}
+	if i, ok := p.index16(); ok {
+		return []byte(palette[i].name), nil
+	}
+
+	r, g, b, ok := p.RGB()
+	if !ok {
+		return nil, fmt.Errorf("color: can't marshal paint %q", string(p))
+	}
+	return []byte(fmt.Sprintf("#%02x%02x%02x", r, g, b)), nil
+}
+
+// UnmarshalText sets the paint to the one described by text, as understood
+// by ParsePaint.
+func (p *Paint) UnmarshalText(text []byte) error {
+	parsed, err := ParsePaint(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
@@ -0,0 +1,139 @@
+package color
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var parsePaintTT = []struct {
+	s    string
+	want Paint
+}{
+	{"red", RedPaint},
+	{"Dark Blue", DarkBluePaint},
+	{"light-gray", LightGrayPaint},
+	{"DARK_PURPLE", DarkPurplePaint},
+	{"#ff8800", PaintRGB(255, 136, 0)},
+	{"00FF00", PaintRGB(0, 255, 0)},
+	{"", ""},
+}
+
+func TestParsePaint(t *testing.T) {
+	for _, test := range parsePaintTT {
+		got, err := ParsePaint(test.s)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.s, err)
+		}
+		if got != test.want {
+			t.Errorf("%q: Want %#v, got %#v", test.s, test.want, got)
+		}
+	}
+}
+
+func TestParsePaintInvalid(t *testing.T) {
+	for _, s := range []string{"blurple", "#ff88", "#gg8800"} {
+		if p, err := ParsePaint(s); err == nil {
+			t.Errorf("%q: want an error, got %#v", s, p)
+		}
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		want Paint
+	}{
+		{"#ff8800", PaintRGB(255, 136, 0)},
+		{"ff8800", PaintRGB(255, 136, 0)},
+		{"#F80", PaintRGB(255, 136, 0)},
+		{"f80", PaintRGB(255, 136, 0)},
+		{"#000000", PaintRGB(0, 0, 0)},
+	} {
+		got, err := ParseHex(test.s)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.s, err)
+		}
+		if got != test.want {
+			t.Errorf("%q: Want %#v, got %#v", test.s, test.want, got)
+		}
+	}
+
+	want := "\033[1;37m" + "\033[48;2;255;136;0m" + "x" + "\033[0m"
+	orange, _ := ParseHex("#f80")
+	if got := NewStyle("", WhitePaint).WithBackground(orange).Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestParseHexInvalid(t *testing.T) {
+	for _, test := range []struct {
+		s, err string
+	}{
+		{"#ff88", `color: invalid hex color "#ff88", want 3 or 6 hex digits`},
+		{"", `color: invalid hex color "", want 3 or 6 hex digits`},
+		{"##ff8800", `color: invalid hex color "##ff8800", want 3 or 6 hex digits`},
+		{"#gg8800", `color: invalid hex color "#gg8800", not a hex number`},
+		{"+f8", `color: invalid hex color "+f8", not a hex number`},
+	} {
+		p, err := ParseHex(test.s)
+		if err == nil {
+			t.Errorf("%q: want an error, got %#v", test.s, p)
+		} else if err.Error() != test.err {
+			t.Errorf("%q: Want %#v, got %#v", test.s, test.err, err.Error())
+		}
+	}
+}
+
+func TestDefineAlias(t *testing.T) {
+	defer func() {
+		delete(aliases.paints, "primary")
+		delete(aliases.paints, "red")
+	}()
+
+	primary := PaintRGB(0x00, 0x7a, 0xcc)
+	DefineAlias("Primary", primary)
+	for _, s := range []string{"primary", "PRIMARY", "pri-mary"} {
+		if got, err := ParsePaint(s); err != nil || got != primary {
+			t.Errorf("%q: Want %#v, got %#v, %v", s, primary, got, err)
+		}
+	}
+
+	theme, err := LoadTheme(strings.NewReader(`{"title": "primary"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := NewStyle("", primary).Brush()("x"), theme["title"].Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// aliases replace standard names
+	DefineAlias("red", PaintRGB(200, 40, 40))
+	if got, _ := ParsePaint("red"); got != PaintRGB(200, 40, 40) {
+		t.Errorf("Want the alias, got %#v", got)
+	}
+}
+
+func TestPaintTextMarshaling(t *testing.T) {
+	paints := []Paint{RedPaint, DarkCyanPaint, PaintRGB(1, 2, 255)}
+
+	data, err := json.Marshal(paints)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `["red","darkcyan","#0102ff"]`
+	if string(data) != want {
+		t.Errorf("Want %s, got %s", want, data)
+	}
+
+	var got []Paint
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	for i := range paints {
+		if got[i] != paints[i] {
+			t.Errorf("Want %#v, got %#v", paints[i], got[i])
+		}
+	}
+}
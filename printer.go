@@ -0,0 +1,46 @@
+package color
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Printer prints to a writer with a style, like the Print functions of
+// package fmt.  Its colors are downsampled to what its level can display,
+// and it prints plain text at LevelNone.
+type Printer struct {
+	w     io.Writer
+	style Style
+}
+
+// NewPrinter gives you a printer writing to w with the style, displayed at
+// the given level.
+func NewPrinter(w io.Writer, style Style, level Level) *Printer {
+	p := &Printer{w: w}
+	if level > LevelNone {
+		p.style = style.downsample(level)
+	}
+	return p
+}
+
+// Print formats like fmt.Print and writes the painted result.
+func (p *Printer) Print(a ...interface{}) (n int, err error) {
+	return io.WriteString(p.w, p.paint(fmt.Sprint(a...)))
+}
+
+// Printf formats like fmt.Printf and writes the painted result.
+func (p *Printer) Printf(format string, a ...interface{}) (n int, err error) {
+	return io.WriteString(p.w, p.paint(fmt.Sprintf(format, a...)))
+}
+
+// Println formats like fmt.Println and writes the painted result.  The
+// newline is written after the reset.
+func (p *Printer) Println(a ...interface{}) (n int, err error) {
+	text := strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return io.WriteString(p.w, p.paint(text)+"\n")
+}
+
+func (p *Printer) paint(text string) string {
+	return p.style.colorize(text)
+}
@@ -0,0 +1,48 @@
+package color
+
+import (
+	"bytes"
+	"testing"
+)
+
+var printerTT = []struct {
+	level Level
+	want  string
+}{
+	{LevelTrueColor, "\033[38;2;250;10;10m" + "retry 1 of 3" + "\033[0m"},
+	{Level256, "\033[38;5;196m" + "retry 1 of 3" + "\033[0m"},
+	{Level16, "\033[1;31m" + "retry 1 of 3" + "\033[0m"},
+	{LevelNone, "retry 1 of 3"},
+}
+
+func TestPrinterPrintf(t *testing.T) {
+	style := NewStyle("", PaintRGB(250, 10, 10))
+	for _, test := range printerTT {
+		var buf bytes.Buffer
+		p := NewPrinter(&buf, style, test.level)
+
+		n, err := p.Printf("retry %d of %d", 1, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != test.want {
+			t.Errorf("level %d: Want %#v, got %#v", test.level, test.want, got)
+		}
+		if n != buf.Len() {
+			t.Errorf("level %d: want n=%d, got %d", test.level, buf.Len(), n)
+		}
+	}
+}
+
+func TestPrinterPrintAndPrintln(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(&buf, NewStyle("", GreenPaint), Level16)
+
+	p.Print("ok ", 1)
+	p.Println("done", 2)
+
+	want := Green("ok 1") + Green("done 2") + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
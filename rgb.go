@@ -0,0 +1,175 @@
+package color
+
+import (
+	imagecolor "image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PaintRGB gives you a 24-bit truecolor paint, for terminals that support
+// it.  It can be used as a foreground or a background like any other Paint.
+func PaintRGB(r, g, b uint8) Paint {
+	return Paint("38;2;" +
+		strconv.Itoa(int(r)) + ";" +
+		strconv.Itoa(int(g)) + ";" +
+		strconv.Itoa(int(b)))
+}
+
+// RGB gives the red, green and blue components of the paint.  The standard
+// and 256 colors paints give the RGB value xterm uses to display them.  ok
+// is false if the paint has no known RGB value.
+func (p Paint) RGB() (r, g, b uint8, ok bool) {
+	if i, ok := p.index16(); ok {
+		c := palette[i]
+		return c.r, c.g, c.b, true
+	}
+	if i, ok := p.index256(); ok {
+		r, g, b := rgb256(i)
+		return r, g, b, true
+	}
+
+	if !strings.HasPrefix(string(p), "38;2;") {
+		return 0, 0, 0, false
+	}
+	parts := strings.Split(string(p)[len("38;2;"):], ";")
+	if len(parts) != 3 {
+		return 0, 0, 0, false
+	}
+	var rgb [3]uint8
+	for i, part := range parts {
+		v, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		rgb[i] = uint8(v)
+	}
+	return rgb[0], rgb[1], rgb[2], true
+}
+
+// Blend mixes two paints into a truecolor paint, t of the way from a to b.
+// t is clamped between 0 and 1.  If one of the paints has no RGB value, the
+// other one is returned unchanged.
+func Blend(a, b Paint, t float64) Paint {
+	ar, ag, ab, aok := a.RGB()
+	br, bg, bb, bok := b.RGB()
+	switch {
+	case !aok:
+		return b
+	case !bok:
+		return a
+	}
+
+	t = clamp01(t)
+	return PaintRGB(lerp(ar, br, t), lerp(ag, bg, t), lerp(ab, bb, t))
+}
+
+// Fade makes a paint look t transparent over the background toward, for
+// de-emphasized text, i.e. half way to a dark background:
+//
+//	dimmed := color.Fade(color.RedPaint, color.PaintRGB(0x1e, 0x1e, 0x2e), 0.5)
+//
+// t is clamped between 0 and 1, and the paints are given back unchanged at
+// either end.  Faded colors are truecolor paints.
+func Fade(p, toward Paint, t float64) Paint {
+	switch {
+	case t <= 0:
+		return p
+	case t >= 1:
+		return toward
+	}
+	return Blend(p, toward, t)
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+}
+
+// HSV gives you the truecolor paint of a color given by its hue, in degrees,
+// and its saturation and value, between 0 and 1.
+func HSV(h, s, v float64) Paint {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s = clamp01(s)
+	v = clamp01(v)
+
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return PaintRGB(toByte(r+m), toByte(g+m), toByte(b+m))
+}
+
+// Categorical gives you n distinct truecolor paints for series of a chart
+// or entries of a legend, with hues evenly spaced around the color wheel
+// from blue.  Past 6 paints, every other one is darker, so that neighbors
+// with close hues still stand apart.
+func Categorical(n int) []Paint {
+	if n <= 0 {
+		return nil
+	}
+	paints := make([]Paint, n)
+	for i := range paints {
+		v := 0.95
+		if n > 6 && i%2 == 1 {
+			v = 0.7
+		}
+		paints[i] = HSV(210+float64(i)*360/float64(n), 0.7, v)
+	}
+	return paints
+}
+
+// FromColor gives you the truecolor paint of a color of package
+// image/color.  Colors with some transparency are painted as if they were
+// opaque, and fully transparent ones give the empty paint.
+func FromColor(c imagecolor.Color) Paint {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return ""
+	}
+	// RGBA is premultiplied by alpha
+	r, g, b = r*0xffff/a, g*0xffff/a, b*0xffff/a
+	return PaintRGB(uint8(r>>8), uint8(g>>8), uint8(b>>8))
+}
+
+// RGBA gives the RGB value of the paint as an opaque color, so that Paint
+// is a color of package image/color.  Paints without RGB value, such as the
+// empty paint, are fully transparent.
+func (p Paint) RGBA() (r, g, b, a uint32) {
+	r8, g8, b8, ok := p.RGB()
+	if !ok {
+		return 0, 0, 0, 0
+	}
+	return uint32(r8) * 0x101, uint32(g8) * 0x101, uint32(b8) * 0x101, 0xffff
+}
+
+func clamp01(t float64) float64 {
+	switch {
+	case t < 0:
+		return 0
+	case t > 1:
+		return 1
+	}
+	return t
+}
+
+func toByte(t float64) uint8 {
+	return uint8(clamp01(t)*255 + 0.5)
+}
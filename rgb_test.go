@@ -0,0 +1,187 @@
+package color
+
+import (
+	imagecolor "image/color"
+	"reflect"
+	"testing"
+)
+
+func TestPaintRGB(t *testing.T) {
+	want := "\033[38;2;255;136;0m" + "orange" + "\033[0m"
+	if got := NewBrush("", PaintRGB(255, 136, 0))("orange"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want = "\033[38;2;255;255;255m" + "\033[48;2;0;0;0m" + "inverted" + "\033[0m"
+	if got := NewBrush(PaintRGB(0, 0, 0), PaintRGB(255, 255, 255))("inverted"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	// mixed with the 16 colors, through the With methods
+	style := NewStyle("", YellowPaint).WithForeground(PaintRGB(18, 52, 86)).WithBackground(DarkBluePaint)
+	want = "\033[38;2;18;52;86m" + "\033[44m" + "mixed" + "\033[0m"
+	if got := style.Brush()("mixed"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	style = NewStyle(BluePaint, "38;2;0;0;0")
+	want = "\033[38;2;0;0;0m" + "\033[44m" + "mixed" + "\033[0m"
+	if got := style.Brush()("mixed"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+var rgbTT = []struct {
+	p       Paint
+	r, g, b uint8
+	ok      bool
+}{
+	{PaintRGB(1, 2, 3), 1, 2, 3, true},
+	{RedPaint, 255, 0, 0, true},
+	{BlackPaint, 0, 0, 0, true},
+	{"91", 255, 0, 0, true},
+	{"", 0, 0, 0, false},
+	{"38;2;1;2", 0, 0, 0, false},
+}
+
+func TestPaintToRGB(t *testing.T) {
+	for _, test := range rgbTT {
+		r, g, b, ok := test.p.RGB()
+		if r != test.r || g != test.g || b != test.b || ok != test.ok {
+			t.Errorf("%#v: want (%d, %d, %d, %v), got (%d, %d, %d, %v)",
+				test.p, test.r, test.g, test.b, test.ok, r, g, b, ok)
+		}
+	}
+}
+
+var blendTT = []struct {
+	a, b Paint
+	t    float64
+	want Paint
+}{
+	{BlackPaint, WhitePaint, 0, PaintRGB(0, 0, 0)},
+	{BlackPaint, WhitePaint, 1, PaintRGB(255, 255, 255)},
+	{BlackPaint, WhitePaint, 0.5, PaintRGB(128, 128, 128)},
+	{BlackPaint, WhitePaint, 2, PaintRGB(255, 255, 255)},
+	{PaintRGB(0, 100, 200), PaintRGB(100, 0, 100), 0.25, PaintRGB(25, 75, 175)},
+	{"", RedPaint, 0.5, RedPaint},
+	{RedPaint, "", 0.5, RedPaint},
+}
+
+func TestBlend(t *testing.T) {
+	for _, test := range blendTT {
+		if got := Blend(test.a, test.b, test.t); got != test.want {
+			t.Errorf("Blend(%#v, %#v, %v): Want %#v, got %#v", test.a, test.b, test.t, test.want, got)
+		}
+	}
+}
+
+func TestFade(t *testing.T) {
+	bg := PaintRGB(0, 0, 0)
+	for _, test := range []struct {
+		t    float64
+		want Paint
+	}{
+		{0, RedPaint},
+		{-1, RedPaint},
+		{1, bg},
+		{2, bg},
+		{0.5, PaintRGB(128, 0, 0)},
+	} {
+		if got := Fade(RedPaint, bg, test.t); got != test.want {
+			t.Errorf("Fade(%v): Want %#v, got %#v", test.t, test.want, got)
+		}
+	}
+}
+
+var hsvTT = []struct {
+	h, s, v float64
+	want    Paint
+}{
+	{0, 1, 1, PaintRGB(255, 0, 0)},
+	{120, 1, 1, PaintRGB(0, 255, 0)},
+	{240, 1, 1, PaintRGB(0, 0, 255)},
+	{360 + 60, 1, 1, PaintRGB(255, 255, 0)},
+	{-60, 1, 1, PaintRGB(255, 0, 255)},
+	{0, 0, 0.5, PaintRGB(128, 128, 128)},
+	{0, 0, 0, PaintRGB(0, 0, 0)},
+}
+
+func TestHSV(t *testing.T) {
+	for _, test := range hsvTT {
+		if got := HSV(test.h, test.s, test.v); got != test.want {
+			t.Errorf("HSV(%v, %v, %v): Want %#v, got %#v", test.h, test.s, test.v, test.want, got)
+		}
+	}
+}
+
+func TestCategorical(t *testing.T) {
+	if want, got := []Paint{HSV(210, 0.7, 0.95)}, Categorical(1); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if got := Categorical(0); got != nil {
+		t.Errorf("Want no paints, got %#v", got)
+	}
+
+	for _, n := range []int{2, 3, 6, 7, 12} {
+		paints := Categorical(n)
+		if len(paints) != n {
+			t.Fatalf("Want %d paints, got %d", n, len(paints))
+		}
+
+		seen := make(map[Paint]bool)
+		for i, p := range paints {
+			if seen[p] {
+				t.Errorf("n=%d: paint %d is repeated: %#v", n, i, p)
+			}
+			seen[p] = true
+
+			r1, g1, b1, _ := p.RGB()
+			r2, g2, b2, _ := paints[(i+1)%n].RGB()
+			if d := CIEDE2000.distance(r1, g1, b1, r2, g2, b2); d < 10 {
+				t.Errorf("n=%d: paints %d and %d are too close: %v", n, i, i+1, d)
+			}
+		}
+	}
+}
+
+var fromColorTT = []struct {
+	c    imagecolor.Color
+	want Paint
+}{
+	{imagecolor.RGBA{255, 0, 0, 255}, PaintRGB(255, 0, 0)},
+	{imagecolor.RGBA{0x80, 0x40, 0, 0x80}, PaintRGB(255, 127, 0)},
+	{imagecolor.NRGBA{255, 136, 0, 0x80}, PaintRGB(255, 136, 0)},
+	{imagecolor.Gray{0x80}, PaintRGB(0x80, 0x80, 0x80)},
+	{imagecolor.Transparent, ""},
+}
+
+func TestFromColor(t *testing.T) {
+	for _, test := range fromColorTT {
+		if got := FromColor(test.c); got != test.want {
+			t.Errorf("%#v: Want %#v, got %#v", test.c, test.want, got)
+		}
+	}
+}
+
+func TestPaintRGBA(t *testing.T) {
+	var c imagecolor.Color = PaintRGB(255, 136, 0)
+
+	r, g, b, a := c.RGBA()
+	if r != 0xffff || g != 0x8888 || b != 0 || a != 0xffff {
+		t.Errorf("Want (0xffff, 0x8888, 0, 0xffff), got (%#x, %#x, %#x, %#x)", r, g, b, a)
+	}
+	if got := FromColor(c); got != PaintRGB(255, 136, 0) {
+		t.Errorf("Want the paint back, got %#v", got)
+	}
+
+	want := imagecolor.NRGBA{0xcd, 0, 0, 0xff}
+	if got := imagecolor.NRGBAModel.Convert(DarkRedPaint); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	r, g, b, a = Paint("").RGBA()
+	if r != 0 || g != 0 || b != 0 || a != 0 {
+		t.Errorf("Want the empty paint transparent, got (%#x, %#x, %#x, %#x)", r, g, b, a)
+	}
+}
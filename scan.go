@@ -0,0 +1,423 @@
+package color
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	esc  = '\033'
+	csi8 = '\233' // the 8-bit introducer of Use8BitCSI
+)
+
+// Span is a run of text painted with a single style.  Plain text has the
+// zero Style.
+type Span struct {
+	Style Style
+	Text  string
+}
+
+// Parse splits a colored string into the spans of text it is made of, with
+// the style each of them is painted with.  Standard colors are given in
+// their `3x`/`9x` form, the bright paints of this package being parsed as a
+// dark paint with the Bold attribute, which is how terminals interpret them.
+// Escape sequences other than colors and attributes are dropped.
+func Parse(s string) []Span {
+	var sc Scanner
+	sc.Write([]byte(s))
+	sc.Flush()
+
+	var spans []Span
+	for span, ok := sc.Next(); ok; span, ok = sc.Next() {
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// StyledRange is a run of a plain text painted with a single style, from
+// byte Start up to byte End.
+type StyledRange struct {
+	Start, End int
+	Style      Style
+}
+
+// Split separates a colored string into its plain text and the ranges of
+// that text painted with a style, like Parse does, so that the plain text
+// can be searched and its matches mapped back to their styles, i.e:
+//
+//	plain, ranges := color.Split(out)
+//	i := strings.Index(plain, "FAIL")
+//	// the ranges holding i tell how FAIL was painted
+//
+// The plain parts of the text have no range.
+func Split(s string) (plain string, ranges []StyledRange) {
+	var buf bytes.Buffer
+	for _, span := range Parse(s) {
+		start := buf.Len()
+		buf.WriteString(span.Text)
+		if span.Style != (Style{}) {
+			ranges = append(ranges, StyledRange{start, buf.Len(), span.Style})
+		}
+	}
+	return buf.String(), ranges
+}
+
+// Scanner is the streaming counterpart of Parse.  Colored input is written
+// to it as it arrives, in chunks of any size, and Next gives the spans as
+// they are completed.  Escape sequences split across writes are buffered
+// until they are complete.
+type Scanner struct {
+	pending   []byte // input not yet scanned, an incomplete escape sequence
+	style     Style  // style set by the sequences scanned so far
+	textStyle Style  // style of the current span
+	text      []byte // text of the current span
+	spans     []Span // complete spans not yet returned by Next
+}
+
+// Write scans p.  It never fails.
+func (sc *Scanner) Write(p []byte) (int, error) {
+	sc.pending = append(sc.pending, p...)
+	sc.scan()
+	return len(p), nil
+}
+
+// Next gives the next complete span, if any.  A span is complete once text
+// painted with another style follows it, or once Flush is called.
+func (sc *Scanner) Next() (Span, bool) {
+	if len(sc.spans) == 0 {
+		return Span{}, false
+	}
+	span := sc.spans[0]
+	sc.spans = sc.spans[1:]
+	return span, true
+}
+
+// Flush completes the current span, to be called at the end of the input.
+// An incomplete escape sequence left at the end of the input is dropped.
+func (sc *Scanner) Flush() {
+	if len(sc.pending) > 0 && !isIntroducer(sc.pending[0]) {
+		// a truncated rune, it's text
+		sc.addText(sc.pending)
+	}
+	sc.pending = sc.pending[:0]
+	sc.endSpan()
+}
+
+func (sc *Scanner) scan() {
+	buf := sc.pending
+	for len(buf) > 0 {
+		i := indexIntroducer(buf)
+		if i < 0 {
+			// the last rune may be split, its end could be taken for
+			// the 8-bit introducer
+			n := len(buf) - partialRuneLen(buf)
+			sc.addText(buf[:n])
+			buf = buf[n:]
+			break
+		}
+		sc.addText(buf[:i])
+		buf = buf[i:]
+
+		n, ok := escapeLen(buf)
+		if !ok {
+			// wait for the rest of the sequence
+			break
+		}
+		if params, ok := sgrParams(buf[:n]); ok {
+			sc.style = applySGR(sc.style, params)
+		}
+		buf = buf[n:]
+	}
+	sc.pending = append(sc.pending[:0], buf...)
+}
+
+func (sc *Scanner) addText(text []byte) {
+	if len(text) == 0 {
+		return
+	}
+	if sc.style != sc.textStyle {
+		sc.endSpan()
+		sc.textStyle = sc.style
+	}
+	sc.text = append(sc.text, text...)
+}
+
+func (sc *Scanner) endSpan() {
+	if len(sc.text) == 0 {
+		return
+	}
+	sc.spans = append(sc.spans, Span{Style: sc.textStyle, Text: string(sc.text)})
+	sc.text = sc.text[:0]
+}
+
+// eachToken calls fn with each run of text and each escape sequence of s,
+// in order.  A truncated escape sequence at the end of s is given as text.
+func eachToken(s string, fn func(tok string, escape bool)) {
+	b := []byte(s)
+	for len(b) > 0 {
+		i := indexIntroducer(b)
+		if i < 0 {
+			fn(string(b), false)
+			return
+		}
+		if i > 0 {
+			fn(string(b[:i]), false)
+			b = b[i:]
+		}
+
+		n, ok := escapeLen(b)
+		if !ok {
+			fn(string(b), false)
+			return
+		}
+		fn(string(b[:n]), true)
+		b = b[n:]
+	}
+}
+
+// Strip gives the plain text of a colored string, without its escape
+// sequences, i.e. to search or measure colored log lines:
+//
+//	if strings.Contains(color.Strip(line), "timeout") {
+//
+// A truncated escape sequence at the end of s is dropped too.  Text without
+// escape sequences is given back unchanged.
+func Strip(s string) string {
+	if strings.IndexByte(s, esc) < 0 && strings.IndexByte(s, csi8) < 0 {
+		return s
+	}
+	var buf bytes.Buffer
+	eachToken(s, func(tok string, escape bool) {
+		if !escape && !isIntroducer(tok[0]) {
+			buf.WriteString(tok)
+		}
+	})
+	return buf.String()
+}
+
+// VisibleLen gives the number of runes of the plain text of s, as given by
+// Strip, i.e. to align columns of colored cells.
+func VisibleLen(s string) int {
+	n := 0
+	eachToken(s, func(tok string, escape bool) {
+		if !escape && !isIntroducer(tok[0]) {
+			n += utf8.RuneCountInString(tok)
+		}
+	})
+	return n
+}
+
+// isIntroducer tells if c starts an escape sequence, when it isn't part of
+// a rune.
+func isIntroducer(c byte) bool {
+	return c == esc || c == csi8
+}
+
+// indexIntroducer gives the index of the first escape sequence of b, or -1.
+// The bytes of the runes encoded in UTF-8 are skipped, since the 8-bit
+// introducer is also the continuation byte of some of them.
+func indexIntroducer(b []byte) int {
+	if bytes.IndexByte(b, csi8) < 0 {
+		return bytes.IndexByte(b, esc)
+	}
+	for i := 0; i < len(b); {
+		switch c := b[i]; {
+		case isIntroducer(c):
+			return i
+		case c < utf8.RuneSelf:
+			i++
+		default:
+			_, size := utf8.DecodeRune(b[i:])
+			i += size
+		}
+	}
+	return -1
+}
+
+// partialRuneLen gives the length of the truncated rune b ends with, if
+// any.
+func partialRuneLen(b []byte) int {
+	for n := 1; n < utf8.UTFMax && n <= len(b); n++ {
+		if tail := b[len(b)-n:]; utf8.RuneStart(tail[0]) {
+			if tail[0] >= utf8.RuneSelf && !utf8.FullRune(tail) {
+				return n
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// escapeLen gives the length of the escape sequence at the start of b,
+// started by ESC or the 8-bit introducer. ok is false if b ends before the
+// sequence does.  Malformed sequences end at the first byte that doesn't
+// belong to them.
+func escapeLen(b []byte) (n int, ok bool) {
+	if len(b) > 0 && b[0] == csi8 {
+		return csiLen(b, 1)
+	}
+	if len(b) < 2 {
+		return 0, false
+	}
+
+	switch b[1] {
+	case '[':
+		return csiLen(b, 2)
+	case ']':
+		// OSC: terminated by BEL or ST
+		for i := 2; i < len(b); i++ {
+			switch {
+			case b[i] == '\a':
+				return i + 1, true
+			case b[i] == esc && i+1 < len(b) && b[i+1] == '\\':
+				return i + 2, true
+			}
+		}
+		return 0, false
+	}
+	return 2, true
+}
+
+// csiLen gives the length of the CSI sequence at the start of b, its
+// parameter and intermediate bytes starting at i, then a final byte.
+func csiLen(b []byte, i int) (n int, ok bool) {
+	for ; i < len(b); i++ {
+		switch c := b[i]; {
+		case c >= 0x40 && c <= 0x7e:
+			return i + 1, true
+		case c < 0x20 || c > 0x7e:
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// sgrParams gives the parameters of a complete SGR sequence.
+func sgrParams(seq []byte) (string, bool) {
+	start := 2
+	switch {
+	case len(seq) > 0 && seq[0] == csi8:
+		start = 1
+	case len(seq) < 2 || seq[1] != '[':
+		return "", false
+	}
+	if len(seq) <= start || seq[len(seq)-1] != 'm' {
+		return "", false
+	}
+	return string(seq[start : len(seq)-1]), true
+}
+
+// sgrParam is a single SGR parameter.  n is -1 for invalid parameters.
+type sgrParam struct {
+	raw   string
+	n     int
+	sub   string // sub-parameter after a colon, such as 3 in `4:3`
+	paint Paint  // for 38 and 48, the paint given after them
+}
+
+// splitSGR splits the parameters of an SGR sequence, merging the 38 and 48
+// parameters with the paint that follows them.
+func splitSGR(params string) []sgrParam {
+	codes := strings.Split(params, ";")
+	split := make([]sgrParam, 0, len(codes))
+	for i := 0; i < len(codes); i++ {
+		param := sgrParam{raw: codes[i]}
+		code := codes[i]
+		if j := strings.IndexByte(code, ':'); j >= 0 {
+			code, param.sub = code[:j], code[j+1:]
+		}
+		if code != "" {
+			var err error
+			if param.n, err = strconv.Atoi(code); err != nil {
+				param.n = -1
+			}
+		}
+
+		if param.n == 38 || param.n == 48 {
+			p, used := extendedPaint(codes[i+1:])
+			param.raw = strings.Join(codes[i:i+1+used], ";")
+			param.paint = p
+			i += used
+		}
+		split = append(split, param)
+	}
+	return split
+}
+
+// applySGR gives the style resulting from applying SGR parameters on top of
+// s.
+func applySGR(s Style, params string) Style {
+	bg, fg, attrs := s.bg, s.fg, s.attrs
+
+	for _, param := range splitSGR(params) {
+		switch n := param.n; {
+		case n == 0:
+			bg, fg, attrs = "", "", ""
+		case n == 4 && param.sub != "":
+			attrs = removeAttributes(attrs, underlines...)
+			if param.sub != "0" {
+				attrs = addAttributes(attrs, Attribute("4:"+param.sub))
+			}
+		case n >= 1 && n <= 9:
+			attrs = addAttributes(attrs, Attribute(strconv.Itoa(n)))
+		case n == 22:
+			attrs = removeAttributes(attrs, Bold, Dim)
+		case n == 23:
+			attrs = removeAttributes(attrs, Italic)
+		case n == 24:
+			attrs = removeAttributes(attrs, underlines...)
+		case n == 25:
+			attrs = removeAttributes(attrs, Blink, RapidBlink)
+		case n == 27:
+			attrs = removeAttributes(attrs, Reverse)
+		case n == 28:
+			attrs = removeAttributes(attrs, Conceal)
+		case n == 29:
+			attrs = removeAttributes(attrs, Strikethrough)
+		case n >= 30 && n <= 37, n >= 90 && n <= 97:
+			fg = Paint(strconv.Itoa(n))
+		case n >= 40 && n <= 47, n >= 100 && n <= 107:
+			bg = Paint(strconv.Itoa(n - 10))
+		case n == 38 && param.paint != "":
+			fg = param.paint
+		case n == 48 && param.paint != "":
+			bg = param.paint
+		case n == 39:
+			fg = ""
+		case n == 49:
+			bg = ""
+		}
+	}
+
+	if bg == "" && fg == "" && attrs == "" {
+		return Style{}
+	}
+	next := Style{bg: bg, fg: fg, attrs: attrs}
+	next.code, next.csi = computeColorCode(bg, fg, attrs)
+	return next
+}
+
+// extendedPaint gives the 256 or truecolor paint following a 38 or 48
+// parameter, and the number of parameters it used.
+func extendedPaint(codes []string) (Paint, int) {
+	switch {
+	case len(codes) >= 2 && codes[0] == "5":
+		if _, err := strconv.ParseUint(codes[1], 10, 8); err != nil {
+			return "", 2
+		}
+		return Paint("38;5;" + codes[1]), 2
+	case len(codes) >= 4 && codes[0] == "2":
+		var rgb [3]uint8
+		for i := range rgb {
+			v, err := strconv.ParseUint(codes[i+1], 10, 8)
+			if err != nil {
+				return "", 4
+			}
+			rgb[i] = uint8(v)
+		}
+		return PaintRGB(rgb[0], rgb[1], rgb[2]), 4
+	}
+	return "", 0
+}
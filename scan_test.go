@@ -0,0 +1,187 @@
+package color
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParse(t *testing.T) {
+	boldRed := NewStyle("", "31").WithAttributes(Bold)
+	yellowOnBlue := NewStyle("34", "33").WithAttributes(Bold)
+
+	s := Red("error:") + " plain " + NewBrush(BluePaint, YellowPaint)("warn") +
+		NewBrush("", PaintRGB(1, 2, 3))("rgb")
+
+	want := []Span{
+		{boldRed, "error:"},
+		{Style{}, " plain "},
+		{yellowOnBlue, "warn"},
+		{NewStyle("", PaintRGB(1, 2, 3)), "rgb"},
+	}
+
+	if got := Parse(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	boldRed := NewStyle("", "31").WithAttributes(Bold)
+	s := Red("error:") + " disk " + NewBrush("", PaintRGB(1, 2, 3))("full") + " on " + Green("/dév")
+
+	plain, ranges := Split(s)
+	if want := "error: disk full on /dév"; plain != want {
+		t.Errorf("Want %#v, got %#v", want, plain)
+	}
+
+	want := []StyledRange{
+		{0, 6, boldRed},
+		{12, 16, NewStyle("", PaintRGB(1, 2, 3))},
+		{20, 25, NewStyle("", "32").WithAttributes(Bold)},
+	}
+	if !reflect.DeepEqual(ranges, want) {
+		t.Errorf("Want %#v, got %#v", want, ranges)
+	}
+	if got := plain[ranges[1].Start:ranges[1].End]; got != "full" {
+		t.Errorf("Want %#v, got %#v", "full", got)
+	}
+
+	if plain, ranges := Split("plain"); plain != "plain" || ranges != nil {
+		t.Errorf("Want plain text without ranges, got %#v, %#v", plain, ranges)
+	}
+}
+
+var parseTT = []struct {
+	name string
+	s    string
+	want []Span
+}{
+	{"empty", "", nil},
+	{"plain", "plain", []Span{{Style{}, "plain"}}},
+	{"no text", "\033[31m\033[0m", nil},
+	{"merged", "\033[31mred\033[31m still red\033[0m", []Span{
+		{NewStyle("", "31"), "red still red"},
+	}},
+	{"attribute off", "\033[1;4mboth\033[22monly underline", []Span{
+		{Style{}.WithAttributes(Bold, Underline), "both"},
+		{Style{}.WithAttributes(Underline), "only underline"},
+	}},
+	{"256 background", "\033[48;5;200mpink", []Span{
+		{NewStyle("38;5;200", ""), "pink"},
+	}},
+	{"other escapes", "a\033[2Kb\033]0;title\ac", []Span{{Style{}, "abc"}}},
+	{"truncated", "text\033[3", []Span{{Style{}, "text"}}},
+}
+
+func TestParseSequences(t *testing.T) {
+	for _, test := range parseTT {
+		if got := Parse(test.s); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestScannerSplitWrites(t *testing.T) {
+	s := "start " + Red("red") + NewBrush(DarkBluePaint, WhitePaint)("white on blue") + " směr end"
+
+	var sc Scanner
+	var got []Span
+	for i := 0; i < len(s); i++ {
+		sc.Write([]byte{s[i]})
+		for span, ok := sc.Next(); ok; span, ok = sc.Next() {
+			got = append(got, span)
+		}
+	}
+	sc.Flush()
+	for span, ok := sc.Next(); ok; span, ok = sc.Next() {
+		got = append(got, span)
+	}
+
+	if want := Parse(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestScannerIncompleteEscape(t *testing.T) {
+	var sc Scanner
+
+	sc.Write([]byte("hello\033[3"))
+	if span, ok := sc.Next(); ok {
+		t.Errorf("Want no span yet, got %#v", span)
+	}
+
+	sc.Write([]byte("1mworld"))
+	want := Span{Style{}, "hello"}
+	if span, ok := sc.Next(); !ok || span != want {
+		t.Errorf("Want %#v, got %#v", want, span)
+	}
+	if span, ok := sc.Next(); ok {
+		t.Errorf("Want no span yet, got %#v", span)
+	}
+
+	sc.Flush()
+	want = Span{NewStyle("", "31"), "world"}
+	if span, ok := sc.Next(); !ok || span != want {
+		t.Errorf("Want %#v, got %#v", want, span)
+	}
+}
+
+func TestScan8BitCSI(t *testing.T) {
+	defer Use8BitCSI(false)
+	Use8BitCSI(true)
+
+	s := Red("error:") + " směr " + NewBrush(BluePaint, YellowPaint)("warn")
+	want := []Span{
+		{NewStyle("", "31").WithAttributes(Bold), "error:"},
+		{Style{}, " směr "},
+		{NewStyle("34", "33").WithAttributes(Bold), "warn"},
+	}
+	if got := Parse(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if want, got := "error: směr warn", Strip(s); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if !IsBalanced(s) {
+		t.Errorf("Want %q balanced", s)
+	}
+	if !HasStyle(s, NewStyle("", RedPaint)) {
+		t.Errorf("Want the style found in %q", s)
+	}
+	if want, got := Strip(s), Strip(Optimize(s)); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+var stripTT = []struct {
+	name, s, want string
+}{
+	{"plain", "no codes here", "no codes here"},
+	{"empty", "", ""},
+	{"brush", Red("error"), "error"},
+	{"nested", Red("error: " + Blue("file") + " not found"), "error: file not found"},
+	{"attributes", Green.Bold().Underline()("ok") + " " + NewBrush("", Index(208))("warn"), "ok warn"},
+	{"other sequences", "a\033[2Kb\033]8;;http://x\033\\c", "abc"},
+	{"truncated", "done " + Red("x") + "\033[3", "done x"},
+	{"lone escape", "done\033", "done"},
+	{"runes with the 8-bit introducer", "směr " + Red("ě"), "směr ě"},
+}
+
+func TestStrip(t *testing.T) {
+	for _, test := range stripTT {
+		if got := Strip(test.s); got != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestVisibleLen(t *testing.T) {
+	for _, test := range stripTT {
+		if want, got := utf8.RuneCountInString(test.want), VisibleLen(test.s); got != want {
+			t.Errorf("%s: Want %d, got %d", test.name, want, got)
+		}
+	}
+	if got := VisibleLen(Cyan("héllo")); got != 5 {
+		t.Errorf("Want %d, got %d", 5, got)
+	}
+}
@@ -0,0 +1,117 @@
+package color
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// sqlKeywords holds the SQL keywords HighlightSQL paints, in upper case.
+var sqlKeywords = map[string]bool{}
+
+func init() {
+	for _, kw := range strings.Fields(`
+		ADD ALL ALTER AND AS ASC BETWEEN BY CASE CHECK COLUMN CONSTRAINT CREATE
+		CROSS DATABASE DEFAULT DELETE DESC DISTINCT DROP ELSE END EXISTS FALSE
+		FOREIGN FROM FULL GROUP HAVING IN INDEX INNER INSERT INTO IS JOIN KEY
+		LEFT LIKE LIMIT NOT NULL OFFSET ON OR ORDER OUTER PRIMARY REFERENCES
+		RETURNING RIGHT SELECT SET TABLE THEN TRUE UNION UNIQUE UPDATE USING
+		VALUES VIEW WHEN WHERE WITH`) {
+		sqlKeywords[kw] = true
+	}
+}
+
+// HighlightSQL highlights an SQL query with DefaultTheme, i.e:
+//
+//	fmt.Println(color.HighlightSQL("SELECT name FROM users WHERE id = 42"))
+func HighlightSQL(q string) string {
+	return DefaultTheme.HighlightSQL(q)
+}
+
+// HighlightSQL highlights an SQL query, keeping its formatting.  Keywords,
+// whatever their case, string literals, numbers and comments are painted
+// with the "keyword", "string", "number" and "comment" roles of the theme.
+// Identifiers, quoted or not, and operators are left plain.  The query
+// isn't validated.
+func (t Theme) HighlightSQL(q string) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(q); {
+		c := q[i]
+		switch {
+		case strings.HasPrefix(q[i:], "--"):
+			end := strings.IndexByte(q[i:], '\n')
+			if end < 0 {
+				end = len(q) - i
+			}
+			buf.WriteString(t.paint("comment", q[i:i+end]))
+			i += end
+		case strings.HasPrefix(q[i:], "/*"):
+			end := strings.Index(q[i+2:], "*/")
+			if end < 0 {
+				end = len(q) - i
+			} else {
+				end += len("/**/")
+			}
+			buf.WriteString(t.paint("comment", q[i:i+end]))
+			i += end
+		case c == '\'':
+			end := sqlQuotedEnd(q, i)
+			buf.WriteString(t.paint("string", q[i:end]))
+			i = end
+		case c == '"' || c == '`':
+			end := sqlQuotedEnd(q, i)
+			buf.WriteString(q[i:end])
+			i = end
+		case isSQLWordByte(c):
+			end := i + 1
+			for end < len(q) && (isSQLWordByte(q[end]) || q[end] == '.' && isDigits(q[i:end])) {
+				end++
+			}
+			word := q[i:end]
+			switch {
+			case sqlKeywords[strings.ToUpper(word)]:
+				buf.WriteString(t.paint("keyword", word))
+			case isDigits(strings.Replace(word, ".", "", 1)):
+				buf.WriteString(t.paint("number", word))
+			default:
+				buf.WriteString(word)
+			}
+			i = end
+		default:
+			_, n := utf8.DecodeRuneInString(q[i:])
+			buf.WriteString(q[i : i+n])
+			i += n
+		}
+	}
+	return buf.String()
+}
+
+// sqlQuotedEnd gives the index right after the quoted string or identifier
+// starting at i, where doubled quotes stand for the quote itself.
+func sqlQuotedEnd(q string, i int) int {
+	quote := q[i]
+	for j := i + 1; j < len(q); j++ {
+		if q[j] != quote {
+			continue
+		}
+		if j+1 < len(q) && q[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(q)
+}
+
+func isSQLWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= utf8.RuneSelf
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,35 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestHighlightSQL(t *testing.T) {
+	kw := func(s string) string { return DefaultTheme.paint("keyword", s) }
+	str := func(s string) string { return DefaultTheme.paint("string", s) }
+	num := func(s string) string { return DefaultTheme.paint("number", s) }
+	comment := func(s string) string { return DefaultTheme.paint("comment", s) }
+
+	for _, test := range []struct {
+		name string
+		q    string
+		want string
+	}{
+		{"query",
+			"SELECT name, age FROM users WHERE city = 'Paris' AND age > 30",
+			kw("SELECT") + " name, age " + kw("FROM") + " users " + kw("WHERE") + " city = " + str("'Paris'") + " " + kw("AND") + " age > " + num("30")},
+		{"lower case", "select * from t", kw("select") + " * " + kw("from") + " t"},
+		{"word bounded", "SELECT selected, fromage, t1 FROM t2", kw("SELECT") + " selected, fromage, t1 " + kw("FROM") + " t2"},
+		{"escaped quote", "SET s = 'it''s ok'", kw("SET") + " s = " + str("'it''s ok'")},
+		{"quoted identifier", `SELECT "from" FROM "order"`, kw("SELECT") + ` "from" ` + kw("FROM") + ` "order"`},
+		{"decimal", "LIMIT 2.5", kw("LIMIT") + " " + num("2.5")},
+		{"line comment", "SELECT 1 -- only one\nFROM t",
+			kw("SELECT") + " " + num("1") + " " + comment("-- only one") + "\n" + kw("FROM") + " t"},
+		{"block comment", "/* all */ SELECT *", comment("/* all */") + " " + kw("SELECT") + " *"},
+		{"unterminated", "WHERE a = 'open", kw("WHERE") + " a = " + str("'open")},
+	} {
+		if got := HighlightSQL(test.q); got != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, got)
+		}
+	}
+}
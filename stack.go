@@ -0,0 +1,63 @@
+package color
+
+import (
+	"strings"
+)
+
+// ColorizeStack paints a Go stack trace, as printed by panics or given by
+// runtime.Stack, according to DefaultTheme, i.e:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			fmt.Fprintln(os.Stderr, color.ColorizeStack(fmt.Sprintf("panic: %v\n\n%s", r, debug.Stack())))
+//		}
+//	}()
+func ColorizeStack(trace string) string {
+	return DefaultTheme.ColorizeStack(trace)
+}
+
+// ColorizeStack paints a Go stack trace line by line: the panic message
+// with the role "panic", the goroutine headers with "goroutine", the
+// function names with "function", and the file:line locations under them
+// with "location".  Other lines are left plain.
+func (t Theme) ColorizeStack(trace string) string {
+	lines := strings.Split(trace, "\n")
+	for i, line := range lines {
+		lines[i] = t.colorizeStackLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (t Theme) colorizeStackLine(line string) string {
+	trimmed := strings.TrimLeft(line, "\t ")
+	switch {
+	case trimmed == "":
+		return line
+	case strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: "):
+		return t.paint("panic", line)
+	case strings.HasPrefix(line, "goroutine ") && strings.HasSuffix(line, ":"):
+		return t.paint("goroutine", line)
+	case strings.HasPrefix(line, "\t"):
+		// the location, the offset after it is left plain
+		loc, rest := trimmed, ""
+		if i := strings.LastIndex(trimmed, " +0x"); i >= 0 {
+			loc, rest = trimmed[:i], trimmed[i:]
+		}
+		return line[:len(line)-len(trimmed)] + t.paint("location", loc) + rest
+	case strings.HasPrefix(line, "created by "):
+		// created by main.main in goroutine 1
+		fn, rest := strings.TrimPrefix(line, "created by "), ""
+		if j := strings.Index(fn, " in goroutine "); j >= 0 {
+			fn, rest = fn[:j], fn[j:]
+		}
+		return "created by " + t.paint("function", fn) + rest
+	case strings.HasSuffix(line, ")"):
+		// the arguments are in the last parentheses, a receiver such as
+		// in main.(*T).m(...) comes before them
+		j := strings.LastIndex(line, "(")
+		if j > 0 && !strings.Contains(line[:j], " ") {
+			return t.paint("function", line[:j]) + line[j:]
+		}
+	}
+	return line
+}
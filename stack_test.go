@@ -0,0 +1,49 @@
+package color
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleStack = `panic: runtime error: index out of range [5] with length 3
+
+goroutine 1 [running]:
+main.lookup(...)
+	/home/dev/app/main.go:12
+main.(*Server).handle(0xc000012345, {0x4b2f40, 0x3})
+	/home/dev/app/server.go:40 +0x1d
+created by main.main in goroutine 1
+	/home/dev/app/main.go:8 +0x65
+exit status 2 (oops)
+(nothing)`
+
+func TestColorizeStack(t *testing.T) {
+	theme := DefaultTheme
+	panicked, goroutine := theme["panic"].Brush(), theme["goroutine"].Brush()
+	function, location := theme["function"].Brush(), theme["location"].Brush()
+
+	want := []string{
+		panicked("panic: runtime error: index out of range [5] with length 3"),
+		"",
+		goroutine("goroutine 1 [running]:"),
+		function("main.lookup") + "(...)",
+		"\t" + location("/home/dev/app/main.go:12"),
+		function("main.(*Server).handle") + "(0xc000012345, {0x4b2f40, 0x3})",
+		"\t" + location("/home/dev/app/server.go:40") + " +0x1d",
+		"created by " + function("main.main") + " in goroutine 1",
+		"\t" + location("/home/dev/app/main.go:8") + " +0x65",
+		"exit status 2 (oops)",
+		"(nothing)",
+	}
+	got := strings.Split(ColorizeStack(sampleStack), "\n")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	Disable()
+	defer Enable()
+	if got := ColorizeStack(sampleStack); got != sampleStack {
+		t.Errorf("Want the trace unchanged when colors are disabled, got %#v", got)
+	}
+}
@@ -0,0 +1,117 @@
+package color
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// Zebra gives you a function painting table rows alternately with the even
+// and odd styles, by the parity of their index, i.e:
+//
+//	stripe := color.Zebra(even, odd)
+//	for i, row := range rows {
+//		fmt.Println(stripe(i, row))
+//	}
+func Zebra(even, odd Style) func(row int, text string) string {
+	evenBrush, oddBrush := even.Brush(), odd.Brush()
+	return func(row int, text string) string {
+		if row%2 == 0 {
+			return evenBrush(text)
+		}
+		return oddBrush(text)
+	}
+}
+
+// AlignColored lays out rows of possibly colored cells in aligned columns,
+// separated by two spaces.  Unlike text/tabwriter, cells are measured by
+// their DisplayWidth, ignoring their escape sequences and counting the
+// wide East Asian characters as two cells.  Each row ends with a newline.
+func AlignColored(rows [][]string) string {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if n := DisplayWidth(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, row := range rows {
+		for i, cell := range row {
+			buf.WriteString(cell)
+			if i < len(row)-1 {
+				buf.WriteString(strings.Repeat(" ", widths[i]-DisplayWidth(cell)+2))
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+// Alignments of FitCell.
+const (
+	AlignLeft = iota
+	AlignCenter
+	AlignRight
+)
+
+// FitCell pads or cuts a possibly colored cell to exactly width visible
+// runes, for tables whose columns are measured elsewhere.  Shorter content
+// is padded with spaces according to align, AlignLeft, AlignCenter or
+// AlignRight, the extra space of a centered cell going to its right.
+// Longer content is cut with Truncate, which ends it with an ellipsis and
+// closes its styles.  The padding is never painted.
+func FitCell(content string, width int, align int) string {
+	n := VisibleLen(content)
+	if n > width {
+		return Truncate(content, width)
+	}
+
+	pad := width - n
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", pad) + content
+	case AlignCenter:
+		return strings.Repeat(" ", pad/2) + content + strings.Repeat(" ", pad-pad/2)
+	}
+	return content + strings.Repeat(" ", pad)
+}
+
+// ColorizeColumns paints each field of a delimited line, such as a CSV or
+// TSV row, with the style of its column, cycling through the styles when
+// there are more columns than styles, i.e:
+//
+//	fmt.Println(color.ColorizeColumns(row, ',', []color.Style{key, value}))
+//
+// The separators are left plain, and so are empty fields.  Separators within
+// double quotes are part of their field, which is painted with its quotes.
+// Without styles, the line is given back unchanged.
+func ColorizeColumns(line string, sep rune, styles []Style) string {
+	if len(styles) == 0 {
+		return line
+	}
+
+	var buf bytes.Buffer
+	column, start, quoted := 0, 0, false
+	paint := func(end int) {
+		buf.WriteString(styles[column%len(styles)].BrushNonEmpty()(line[start:end]))
+		column++
+	}
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == sep && !quoted:
+			paint(i)
+			buf.WriteRune(r)
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	paint(len(line))
+	return buf.String()
+}
@@ -0,0 +1,118 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestZebra(t *testing.T) {
+	even := NewStyle(DarkGrayPaint, WhitePaint)
+	odd := NewStyle(BlackPaint, WhitePaint)
+	stripe := Zebra(even, odd)
+
+	for row, want := range []string{
+		even.Brush()("row"),
+		odd.Brush()("row"),
+		even.Brush()("row"),
+		odd.Brush()("row"),
+	} {
+		if got := stripe(row, "row"); got != want {
+			t.Errorf("row %d: Want %#v, got %#v", row, want, got)
+		}
+	}
+}
+
+func TestAlignColored(t *testing.T) {
+	got := AlignColored([][]string{
+		{"name", "status", "age"},
+		{Cyan("api"), Green("ok"), "3d"},
+		{Cyan("database"), Red("down"), "12d"},
+	})
+
+	want := "" +
+		"name" + "      " + "status" + "  " + "age\n" +
+		Cyan("api") + "       " + Green("ok") + "      " + "3d\n" +
+		Cyan("database") + "  " + Red("down") + "    " + "12d\n"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestAlignColoredWideRunes(t *testing.T) {
+	got := AlignColored([][]string{
+		{"城市", "ok"},
+		{Cyan("Zürich"), "ok"},
+		{"cafe\u0301", "ok"},
+	})
+
+	want := "城市" + "    " + "ok\n" +
+		Cyan("Zürich") + "  " + "ok\n" +
+		"cafe\u0301" + "    " + "ok\n"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestAlignColoredRaggedRows(t *testing.T) {
+	got := AlignColored([][]string{
+		{"a", "b", "c"},
+		{Red("long")},
+	})
+
+	want := "a     b  c\n" + Red("long") + "\n"
+	if got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestFitCell(t *testing.T) {
+	ok, long := Green("ok"), Red("timeout")
+	for _, test := range []struct {
+		content string
+		width   int
+		align   int
+		want    string
+	}{
+		{ok, 6, AlignLeft, ok + "    "},
+		{ok, 6, AlignRight, "    " + ok},
+		{ok, 6, AlignCenter, "  " + ok + "  "},
+		{ok, 5, AlignCenter, " " + ok + "  "},
+		{ok, 2, AlignRight, ok},
+		{long, 5, AlignLeft, "\033[1;31mtime\033[0m…"},
+		{long, 5, AlignRight, "\033[1;31mtime\033[0m…"},
+		{long, 5, AlignCenter, "\033[1;31mtime\033[0m…"},
+		{"", 3, AlignLeft, "   "},
+	} {
+		got := FitCell(test.content, test.width, test.align)
+		if got != test.want {
+			t.Errorf("%q, %d, %d: Want %#v, got %#v", test.content, test.width, test.align, test.want, got)
+		}
+		if n := VisibleLen(got); n != test.width {
+			t.Errorf("%q, %d, %d: Want %d visible runes, got %d", test.content, test.width, test.align, test.width, n)
+		}
+	}
+}
+
+func TestColorizeColumns(t *testing.T) {
+	key, value := NewStyle("", CyanPaint), NewStyle("", GreenPaint)
+	styles := []Style{key, value}
+	for _, test := range []struct {
+		line string
+		sep  rune
+		want string
+	}{
+		{"api,ok,3d", ',', Cyan("api") + "," + Green("ok") + "," + Cyan("3d")},
+		{"api\tok\t3d", '\t', Cyan("api") + "\t" + Green("ok") + "\t" + Cyan("3d")},
+		{"api,,3d", ',', Cyan("api") + ",," + Cyan("3d")},
+		{`"db, main",down,12d`, ',', Cyan(`"db, main"`) + "," + Green("down") + "," + Cyan("12d")},
+		{`"say ""hi, there""",ok,`, ',', Cyan(`"say ""hi, there"""`) + "," + Green("ok") + ","},
+		{"", ',', ""},
+	} {
+		if got := ColorizeColumns(test.line, test.sep, styles); got != test.want {
+			t.Errorf("Want %#v, got %#v", test.want, got)
+		}
+	}
+
+	if got := ColorizeColumns("a,b", ',', nil); got != "a,b" {
+		t.Errorf("Want %#v, got %#v", "a,b", got)
+	}
+}
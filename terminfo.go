@@ -0,0 +1,422 @@
+package color
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Terminfo gives the sequence turning on the style on the terminal named by
+// the TERM environment variable, as described by its entry in the system
+// terminfo database, for the odd terminals straying from ANSI:
+//
+//	fmt.Print(style.Terminfo(), "text", color.ResetCode())
+//
+// The colors use the set_a_foreground and set_a_background capabilities,
+// and the attributes their own, such as enter_bold_mode.  The parts the
+// entry can't express, such as truecolor paints, strikethrough or raw
+// parameters, are still given as ANSI sequences.  Without a TERM or an
+// entry for it, Terminfo gives the style's own ANSI code.  Terminfo gives an
+// empty string when colors are disabled.
+func (s Style) Terminfo() string {
+	if !Enabled() {
+		return ""
+	}
+	ti := lookupTerminfo(os.Getenv("TERM"))
+	if ti == nil {
+		return s.code
+	}
+
+	bg, fg := s.bg, s.fg
+	if level := ColorLevel(); level < LevelTrueColor {
+		bg, fg = bg.downsample(level), fg.downsample(level)
+	}
+
+	var seq, ansi string
+	attrs := s.attrs
+	if f := string(fg); strings.HasPrefix(f, "0;") {
+		seq = ti.caps[capSgr0]
+	} else if strings.HasPrefix(f, "1;") {
+		attrs = addAttributes(attrs, Bold)
+	}
+	if code, ok := ti.paint(capSetaf, fg); ok {
+		seq += code
+	} else if fg != "" && fg != "39" {
+		ansi = joinParams(ansi, string(compactPaint(fg)))
+	}
+	if code, ok := ti.paint(capSetab, bg); ok {
+		seq += code
+	} else if back, ok := bg.BackgroundCode(); ok {
+		ansi = joinParams(ansi, back)
+	}
+	for _, a := range splitAttributes(attrs) {
+		if c, ok := attributeCaps[Attribute(a)]; ok && ti.caps[c] != "" {
+			seq += ti.caps[c]
+		} else {
+			ansi = joinParams(ansi, a)
+		}
+	}
+	ansi = joinParams(ansi, s.raw)
+	if ansi != "" {
+		seq += sgr(ansi)
+	}
+	return seq
+}
+
+// The indexes of the string capabilities Terminfo uses, in the order of
+// the compiled entries.
+const (
+	capBlink = 26
+	capBold  = 27
+	capDim   = 30
+	capInvis = 32
+	capRev   = 34
+	capSmul  = 36
+	capSgr0  = 39
+	capSitm  = 311
+	capSetaf = 359
+	capSetab = 360
+
+	numColors = 13
+)
+
+var attributeCaps = map[Attribute]int{
+	Bold:      capBold,
+	Dim:       capDim,
+	Italic:    capSitm,
+	Underline: capSmul,
+	Blink:     capBlink,
+	Reverse:   capRev,
+	Conceal:   capInvis,
+}
+
+// terminfo holds the parts of a terminfo entry Terminfo uses.
+type terminfo struct {
+	colors int
+	caps   map[int]string
+}
+
+// paint gives the sequence of a 16 or 256 colors paint from the setaf or
+// setab capability, if the entry has it and enough colors.
+func (ti *terminfo) paint(cap int, p Paint) (string, bool) {
+	i, ok := p.index16()
+	if ok && strings.HasPrefix(string(p), "1;") {
+		// the `1;3x` paints are bold and dark, Terminfo adds the bold
+		i -= 8
+	} else if !ok {
+		n, ok256 := p.index256()
+		i, ok = int(n), ok256
+	}
+	if !ok || i >= ti.colors || ti.caps[cap] == "" {
+		return "", false
+	}
+	return tparm(ti.caps[cap], i), true
+}
+
+var terminfos = struct {
+	sync.Mutex
+	byTerm map[string]*terminfo
+}{byTerm: make(map[string]*terminfo)}
+
+// lookupTerminfo gives the entry of a terminal, read once from the
+// database, or nil if there's none.
+func lookupTerminfo(term string) *terminfo {
+	if term == "" || strings.ContainsAny(term, "/\\") {
+		return nil
+	}
+	dirs := terminfoDirs()
+	key := term + "\x00" + strings.Join(dirs, ":")
+
+	terminfos.Lock()
+	defer terminfos.Unlock()
+	if ti, ok := terminfos.byTerm[key]; ok {
+		return ti
+	}
+	var ti *terminfo
+	for _, dir := range dirs {
+		// entries are filed by their first letter, or its hex code on
+		// case-insensitive file systems
+		for _, sub := range []string{term[:1], fmt.Sprintf("%x", term[0])} {
+			data, err := ioutil.ReadFile(filepath.Join(dir, sub, term))
+			if err != nil {
+				continue
+			}
+			if ti, err = parseTerminfo(data); err == nil {
+				break
+			}
+		}
+		if ti != nil {
+			break
+		}
+	}
+	terminfos.byTerm[key] = ti
+	return ti
+}
+
+// terminfoDirs gives the directories searched for entries, in the order
+// of ncurses.
+func terminfoDirs() []string {
+	var dirs []string
+	if dir := os.Getenv("TERMINFO"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+	for _, dir := range strings.Split(os.Getenv("TERMINFO_DIRS"), ":") {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return append(dirs, "/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo")
+}
+
+var errTerminfo = errors.New("color: invalid terminfo entry")
+
+// parseTerminfo reads a compiled terminfo entry, in the legacy format or
+// the one with 32 bits numbers.
+func parseTerminfo(data []byte) (*terminfo, error) {
+	if len(data) < 12 {
+		return nil, errTerminfo
+	}
+	header := make([]int, 6)
+	for i := range header {
+		header[i] = int(int16(binary.LittleEndian.Uint16(data[2*i:])))
+	}
+	numSize := 2
+	switch header[0] {
+	case 0432:
+	case 01036:
+		numSize = 4
+	default:
+		return nil, errTerminfo
+	}
+	names, bools, nums, strs, table := header[1], header[2], header[3], header[4], header[5]
+	if names < 0 || bools < 0 || nums < 0 || strs < 0 || table < 0 {
+		return nil, errTerminfo
+	}
+
+	// the numbers start on an even offset
+	off := 12 + names + bools
+	off += off % 2
+	end := off + nums*numSize + strs*2 + table
+	if end > len(data) {
+		return nil, errTerminfo
+	}
+
+	ti := &terminfo{caps: make(map[int]string)}
+	if numColors < nums {
+		at := off + numColors*numSize
+		if numSize == 4 {
+			ti.colors = int(int32(binary.LittleEndian.Uint32(data[at:])))
+		} else {
+			ti.colors = int(int16(binary.LittleEndian.Uint16(data[at:])))
+		}
+	}
+	off += nums * numSize
+	strTable := data[off+strs*2 : end]
+	for i := 0; i < strs; i++ {
+		at := int(int16(binary.LittleEndian.Uint16(data[off+2*i:])))
+		if at < 0 || at >= len(strTable) {
+			continue
+		}
+		value := strTable[at:]
+		if nul := strings.IndexByte(string(value), 0); nul >= 0 {
+			value = value[:nul]
+		}
+		ti.caps[i] = string(value)
+	}
+	return ti, nil
+}
+
+// tparm expands the parameters of a terminfo capability, such as the
+// `\E[%?%p1%{8}%<%t3%p1%d%e38;5;%p1%d%;m` of setaf.  It knows the integer
+// operations of terminfo(5), and drops the padding delays.
+func tparm(cap string, params ...int) string {
+	var p [9]int
+	copy(p[:], params)
+	var vars [52]int
+	var stack []int
+	push := func(v int) { stack = append(stack, v) }
+	pop := func() int {
+		if len(stack) == 0 {
+			return 0
+		}
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return v
+	}
+	bool2int := func(b bool) int {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(cap); i++ {
+		c := cap[i]
+		if c == '$' && i+1 < len(cap) && cap[i+1] == '<' {
+			if end := strings.IndexByte(cap[i:], '>'); end >= 0 {
+				i += end
+				continue
+			}
+		}
+		if c != '%' || i+1 == len(cap) {
+			out.WriteByte(c)
+			continue
+		}
+		i++
+		switch op := cap[i]; op {
+		case '%':
+			out.WriteByte('%')
+		case 'c':
+			out.WriteByte(byte(pop()))
+		case 'p':
+			if i+1 < len(cap) && cap[i+1] >= '1' && cap[i+1] <= '9' {
+				i++
+				push(p[cap[i]-'1'])
+			}
+		case 'P', 'g':
+			if i+1 == len(cap) {
+				break
+			}
+			i++
+			var v int
+			switch r := cap[i]; {
+			case r >= 'a' && r <= 'z':
+				v = int(r - 'a')
+			case r >= 'A' && r <= 'Z':
+				v = 26 + int(r-'A')
+			default:
+				continue
+			}
+			if op == 'P' {
+				vars[v] = pop()
+			} else {
+				push(vars[v])
+			}
+		case '\'':
+			if i+2 < len(cap) {
+				push(int(cap[i+1]))
+				i += 2
+			}
+		case '{':
+			end := strings.IndexByte(cap[i:], '}')
+			if end < 0 {
+				break
+			}
+			n, _ := strconv.Atoi(cap[i+1 : i+end])
+			push(n)
+			i += end
+		case 'l':
+			pop()
+			push(0)
+		case 'i':
+			p[0]++
+			p[1]++
+		case '+', '-', '*', '/', 'm', '&', '|', '^', '=', '>', '<', 'A', 'O':
+			b, a := pop(), pop()
+			switch op {
+			case '+':
+				push(a + b)
+			case '-':
+				push(a - b)
+			case '*':
+				push(a * b)
+			case '/':
+				if b != 0 {
+					push(a / b)
+				} else {
+					push(0)
+				}
+			case 'm':
+				if b != 0 {
+					push(a % b)
+				} else {
+					push(0)
+				}
+			case '&':
+				push(a & b)
+			case '|':
+				push(a | b)
+			case '^':
+				push(a ^ b)
+			case '=':
+				push(bool2int(a == b))
+			case '>':
+				push(bool2int(a > b))
+			case '<':
+				push(bool2int(a < b))
+			case 'A':
+				push(bool2int(a != 0 && b != 0))
+			case 'O':
+				push(bool2int(a != 0 || b != 0))
+			}
+		case '!':
+			push(bool2int(pop() == 0))
+		case '~':
+			push(^pop())
+		case '?', ';':
+		case 't':
+			if pop() == 0 {
+				i = skipConditional(cap, i+1, true)
+			}
+		case 'e':
+			i = skipConditional(cap, i+1, false)
+		default:
+			// %[[:]flags][width[.precision]][doxXs]
+			j := i
+			if cap[j] == ':' {
+				j++
+			}
+			for j < len(cap) && strings.IndexByte("doxXs", cap[j]) < 0 {
+				j++
+			}
+			if j == len(cap) {
+				break
+			}
+			verb := cap[j]
+			if verb == 's' {
+				verb = 'd'
+			}
+			format := "%" + strings.TrimPrefix(cap[i:j], ":") + string(verb)
+			out.WriteString(fmt.Sprintf(format, pop()))
+			i = j
+		}
+	}
+	return out.String()
+}
+
+// skipConditional gives the index of the last byte of the `%e`, when
+// elseToo, or `%;` ending the branch starting at i, skipping the nested
+// conditionals.
+func skipConditional(cap string, i int, elseToo bool) int {
+	depth := 0
+	for ; i+1 < len(cap); i++ {
+		if cap[i] != '%' {
+			continue
+		}
+		i++
+		switch cap[i] {
+		case '?':
+			depth++
+		case ';':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		case 'e':
+			if depth == 0 && elseToo {
+				return i
+			}
+		}
+	}
+	return len(cap)
+}
@@ -0,0 +1,155 @@
+package color
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTerminfo compiles a terminfo entry with the given number of colors
+// and string capabilities in the legacy format, into dir.
+func writeTerminfo(t *testing.T, dir, term string, colors int, caps map[int]string) {
+	strs := 0
+	for i := range caps {
+		if i >= strs {
+			strs = i + 1
+		}
+	}
+	var table bytes.Buffer
+	offsets := make([]int16, strs)
+	for i := range offsets {
+		offsets[i] = -1
+		if c, ok := caps[i]; ok {
+			offsets[i] = int16(table.Len())
+			table.WriteString(c)
+			table.WriteByte(0)
+		}
+	}
+
+	names := term + "|test entry\x00"
+	nums := make([]int16, numColors+1)
+	for i := range nums {
+		nums[i] = -1
+	}
+	nums[numColors] = int16(colors)
+
+	var buf bytes.Buffer
+	header := []int16{0432, int16(len(names)), 0, int16(len(nums)), int16(strs), int16(table.Len())}
+	binary.Write(&buf, binary.LittleEndian, header)
+	buf.WriteString(names)
+	if buf.Len()%2 == 1 {
+		buf.WriteByte(0)
+	}
+	binary.Write(&buf, binary.LittleEndian, nums)
+	binary.Write(&buf, binary.LittleEndian, offsets)
+	buf.Write(table.Bytes())
+
+	sub := filepath.Join(dir, term[:1])
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(sub, term), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var xtermCaps = map[int]string{
+	capBlink: "\033[5m",
+	capBold:  "\033[1m",
+	capDim:   "\033[2m",
+	capInvis: "\033[8m",
+	capRev:   "\033[7m",
+	capSmul:  "\033[4m",
+	capSgr0:  "\033(B\033[m",
+	capSitm:  "\033[3m",
+	capSetaf: "\033[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
+	capSetab: "\033[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
+}
+
+func TestTerminfo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeTerminfo(t, dir, "color-test", 256, xtermCaps)
+	writeTerminfo(t, dir, "color-test-8", 8, map[int]string{
+		capBold:  "\033[1m",
+		capSgr0:  "\033[m",
+		capSetaf: "\033[3%p1%dm",
+	})
+	defer setenv("TERMINFO", dir)()
+	defer setenv("TERM", "color-test")()
+
+	for _, test := range []struct {
+		term  string
+		style Style
+		want  string
+	}{
+		{"color-test", NewStyle("", DarkRedPaint), "\033(B\033[m\033[31m"},
+		{"color-test", NewStyle(DarkBluePaint, RedPaint), "\033[31m\033[44m\033[1m"},
+		{"color-test", NewStyle("", Index(208)).WithAttributes(Italic, Underline), "\033[38;5;208m\033[3m\033[4m"},
+		{"color-test", NewStyle("", PaintRGB(1, 2, 3)).WithAttributes(Strikethrough), "\033[38;2;1;2;3;9m"},
+		{"color-test", NewStyle("", "93").WithRaw("53"), "\033[93m\033[53m"},
+		{"color-test-8", NewStyle(BluePaint, DarkGreenPaint).WithAttributes(Bold, Italic), "\033[m\033[32m\033[1m\033[44;3m"},
+		{"color-test-8", NewStyle("", "93"), "\033[93m"},
+	} {
+		os.Setenv("TERM", test.term)
+		if got := test.style.Terminfo(); got != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.term, test.want, got)
+		}
+	}
+
+	style := NewStyle(DarkBluePaint, RedPaint).WithAttributes(Underline)
+	for _, term := range []string{"", "not-a-terminal", "../x/color-test"} {
+		os.Setenv("TERM", term)
+		if got := style.Terminfo(); got != style.code {
+			t.Errorf("%#v: Want %#v, got %#v", term, style.code, got)
+		}
+	}
+
+	Disable()
+	defer Enable()
+	if got := style.Terminfo(); got != "" {
+		t.Errorf("Want %#v, got %#v", "", got)
+	}
+}
+
+func TestParseTerminfoInvalid(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		[]byte("not a terminfo entry"),
+		{0x1a, 0x01, 0, 0, 0, 0, 0, 0, 0x10, 0, 0x10, 0},
+	} {
+		if _, err := parseTerminfo(data); err == nil {
+			t.Errorf("%#v: Want an error, got none", data)
+		}
+	}
+}
+
+func TestTparm(t *testing.T) {
+	setaf := xtermCaps[capSetaf]
+	for _, test := range []struct {
+		cap    string
+		params []int
+		want   string
+	}{
+		{setaf, []int{1}, "\033[31m"},
+		{setaf, []int{9}, "\033[91m"},
+		{setaf, []int{208}, "\033[38;5;208m"},
+		{"\033[%i%p1%d;%p2%dH", []int{3, 4}, "\033[4;5H"},
+		{"\033]4;%p1%d;rgb:%p2%{255}%*%{1000}%/%2.2X\033\\", []int{1, 1000}, "\033]4;1;rgb:FF\033\\"},
+		{"\033[?5h$<100/>\033[?5l", nil, "\033[?5h\033[?5l"},
+		{"%p1%Pa%ga%ga%+%d", []int{21}, "42"},
+		{"%?%p1%t%?%p2%tboth%eone%;%eneither%;", []int{1, 0}, "one"},
+		{"%?%p1%t%?%p2%tboth%eone%;%eneither%;", []int{0, 1}, "neither"},
+		{"%'A'%c%%", nil, "A%"},
+	} {
+		if got := tparm(test.cap, test.params...); got != test.want {
+			t.Errorf("%#v: Want %#v, got %#v", test.cap, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package color
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Theme gives a style to named roles, such as "error" or "keyword", so that
+// applications can be restyled as a whole.
+type Theme map[string]Style
+
+// LoadTheme reads a JSON theme mapping role names to color names or hex
+// colors, which become the foreground of each role, i.e:
+//
+//	{
+//		"error": "red",
+//		"warning": "#ff8800"
+//	}
+func LoadTheme(r io.Reader) (Theme, error) {
+	var roles map[string]string
+	if err := json.NewDecoder(r).Decode(&roles); err != nil {
+		return nil, fmt.Errorf("color: invalid theme: %v", err)
+	}
+
+	theme := make(Theme, len(roles))
+	for role, value := range roles {
+		p, err := ParsePaint(value)
+		if err != nil {
+			return nil, fmt.Errorf("color: invalid theme role %q: %v", role, err)
+		}
+		theme[role] = NewStyle("", p)
+	}
+	return theme, nil
+}
+
+// DefaultTheme is the theme used by the highlighting functions of this
+// package.  It can be changed to restyle them all.
+var DefaultTheme = Theme{
+	// JSON
+	"key":         NewStyle("", BluePaint),
+	"string":      NewStyle("", DarkGreenPaint),
+	"number":      NewStyle("", CyanPaint),
+	"bool":        NewStyle("", YellowPaint),
+	"null":        NewStyle("", DarkGrayPaint),
+	"punctuation": NewStyle("", LightGrayPaint),
+
+	// log levels
+	"debug": NewStyle("", DarkGrayPaint),
+	"info":  NewStyle("", GreenPaint),
+	"warn":  NewStyle("", YellowPaint),
+	"error": NewStyle("", RedPaint),
+	"fatal": NewStyle("", RedPaint).WithAttributes(Bold, Reverse),
+
+	// file names
+	"directory":  NewStyle("", BluePaint).WithAttributes(Bold),
+	"executable": NewStyle("", GreenPaint),
+	"symlink":    NewStyle("", CyanPaint),
+	"archive":    NewStyle("", RedPaint),
+	"image":      NewStyle("", PurplePaint),
+	"audio":      NewStyle("", DarkCyanPaint),
+	"video":      NewStyle("", DarkPurplePaint),
+
+	// stack traces
+	"panic":     NewStyle("", RedPaint).WithAttributes(Bold),
+	"goroutine": NewStyle("", YellowPaint),
+	"function":  NewStyle("", CyanPaint),
+	"location":  NewStyle("", DarkGrayPaint),
+
+	// markdown
+	"code": NewStyle("", DarkCyanPaint),
+
+	// SQL
+	"keyword": NewStyle("", PurplePaint),
+	"comment": NewStyle("", DarkGrayPaint).WithAttributes(Italic),
+}
+
+// Brush gives you a Brush painting with the style of role, or leaving text
+// plain if the theme has no such role, i.e:
+//
+//	warn := theme.Brush("warn")
+//	fmt.Println(warn("disk almost full"))
+func (t Theme) Brush(role string) Brush {
+	b, err := t.CheckedBrush(role)
+	if err != nil {
+		return func(text string) string { return text }
+	}
+	return b
+}
+
+// CheckedBrush is like Brush, but fails for roles the theme doesn't have.
+func (t Theme) CheckedBrush(role string) (Brush, error) {
+	s, ok := t[role]
+	if !ok {
+		return nil, fmt.Errorf("color: no theme role %q", role)
+	}
+	return s.Brush(), nil
+}
+
+// paint paints text with the style of role, leaving it plain if the theme
+// has no such role.
+func (t Theme) paint(role, text string) string {
+	s, ok := t[role]
+	if !ok {
+		return text
+	}
+	return s.colorize(text)
+}
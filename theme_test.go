@@ -0,0 +1,69 @@
+package color
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadTheme(t *testing.T) {
+	theme, err := LoadTheme(strings.NewReader(`{
+		"error": "red",
+		"warning": "#ff8800"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := NewStyle("", RedPaint).Brush()("x")
+	if got := theme["error"].Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	want = NewStyle("", PaintRGB(255, 136, 0)).Brush()("x")
+	if got := theme["warning"].Brush()("x"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestLoadThemeInvalidColor(t *testing.T) {
+	_, err := LoadTheme(strings.NewReader(`{"error": "red", "warning": "blurple"}`))
+	if err == nil {
+		t.Fatal("want an error, got none")
+	}
+	if !strings.Contains(err.Error(), `"warning"`) {
+		t.Errorf("want the error to name the role, got %q", err)
+	}
+}
+
+func TestThemeBrush(t *testing.T) {
+	theme := Theme{"warn": NewStyle("", YellowPaint)}
+
+	if want, got := Yellow("careful"), theme.Brush("warn")("careful"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+	if got := theme.Brush("missing")("plain"); got != "plain" {
+		t.Errorf("Want %#v, got %#v", "plain", got)
+	}
+
+	Disable()
+	defer Enable()
+	if got := theme.Brush("warn")("careful"); got != "careful" {
+		t.Errorf("Want %#v, got %#v", "careful", got)
+	}
+}
+
+func TestThemeCheckedBrush(t *testing.T) {
+	theme := Theme{"warn": NewStyle("", YellowPaint)}
+
+	b, err := theme.CheckedBrush("warn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := Yellow("careful"), b("careful"); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	if _, err := theme.CheckedBrush("missing"); err == nil || !strings.Contains(err.Error(), `"missing"`) {
+		t.Errorf("Want an error naming the role, got %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+package color
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// Faint dims an already colored string without changing its colors, by
+// applying the Dim attribute at the start of the string and again after each
+// of its color sequences, since those can reset it.  Plain strings are
+// simply dimmed.
+//
+// It's named Faint, the other name of SGR 2, since Dim is already the
+// Attribute.
+func Faint(s string) string {
+	if !Enabled() {
+		return s
+	}
+	dim := Dim.code()
+
+	var buf bytes.Buffer
+	needDim := true
+	needReset := false
+	eachToken(s, func(tok string, escape bool) {
+		if escape {
+			if _, ok := sgrParams([]byte(tok)); ok {
+				needDim = true
+				needReset = !isReset(tok)
+			}
+			buf.WriteString(tok)
+			return
+		}
+		if needDim {
+			buf.WriteString(dim)
+			needDim = false
+		}
+		buf.WriteString(tok)
+		needReset = true
+	})
+
+	if needReset {
+		buf.WriteString(ResetCode())
+	}
+	return buf.String()
+}
+
+// isReset tells if an SGR sequence resets all the attributes and colors
+// without setting new ones.
+func isReset(seq string) bool {
+	params, ok := sgrParams([]byte(seq))
+	return ok && (params == "" || params == "0")
+}
+
+// Optimize shrinks a colored string, such as one made of many brushed
+// segments, by dropping each reset that is immediately followed by color
+// sequences leading to the same style with or without it.  The string looks
+// exactly the same once printed.
+func Optimize(s string) string {
+	type token struct {
+		s      string
+		params string
+		sgr    bool
+	}
+	var tokens []token
+	eachToken(s, func(tok string, escape bool) {
+		t := token{s: tok}
+		if escape {
+			t.params, t.sgr = sgrParams([]byte(tok))
+		}
+		tokens = append(tokens, t)
+	})
+
+	var buf bytes.Buffer
+	var state Style
+	for i, tok := range tokens {
+		if !tok.sgr {
+			buf.WriteString(tok.s)
+			continue
+		}
+
+		if isReset(tok.s) {
+			// the color sequences right after the reset
+			next := i + 1
+			for next < len(tokens) && tokens[next].sgr {
+				next++
+			}
+			with, without := Style{}, state
+			for _, t := range tokens[i+1 : next] {
+				with = applySGR(with, t.params)
+				without = applySGR(without, t.params)
+			}
+			if next > i+1 && with == without {
+				continue
+			}
+		}
+
+		buf.WriteString(tok.s)
+		state = applySGR(state, tok.params)
+	}
+	return buf.String()
+}
+
+// Recolor changes the foreground color of an already colored string to fg,
+// rewriting the foreground parameters of each of its SGR sequences while
+// keeping their backgrounds and attributes, i.e:
+//
+//	color.Recolor(color.Red("failed")+" "+color.Yellow("twice"), color.DarkGrayPaint)
+//
+// Sequences made only of foreground parameters are dropped when fg is the
+// empty paint.  Text that had no foreground color isn't colored.
+func Recolor(s string, fg Paint) string {
+	if !Enabled() {
+		return s
+	}
+	params := inlineParams(fg.downsample(ColorLevel()))
+
+	var buf bytes.Buffer
+	eachToken(s, func(tok string, escape bool) {
+		seq, ok := "", false
+		if escape {
+			seq, ok = sgrParams([]byte(tok))
+		}
+		if !ok || isReset(tok) {
+			buf.WriteString(tok)
+			return
+		}
+
+		var kept []string
+		changed := false
+		for _, param := range splitSGR(seq) {
+			switch n := param.n; {
+			case n >= 30 && n <= 37, n >= 90 && n <= 97, n == 38:
+				changed = true
+				if params != "" {
+					kept = append(kept, params)
+				}
+			default:
+				kept = append(kept, param.raw)
+			}
+		}
+		switch {
+		case !changed:
+			buf.WriteString(tok)
+		case len(kept) > 0:
+			buf.WriteString(sgr(strings.Join(kept, ";")))
+		}
+	})
+	return buf.String()
+}
+
+// SafeForPager removes the escape sequences of s that aren't SGR ones, such
+// as cursor moves, line clears or hyperlinks, keeping the colors and
+// attributes.  Pagers like `less -R` only pass SGR sequences through, and
+// the others would garble the page, i.e:
+//
+//	io.WriteString(pager, color.SafeForPager(progressLog))
+//
+// A truncated sequence at the end of s is removed too.
+func SafeForPager(s string) string {
+	var buf bytes.Buffer
+	eachToken(s, func(tok string, escape bool) {
+		switch {
+		case !escape && isIntroducer(tok[0]):
+			// truncated, eachToken only gives it as text at the end
+		case !escape:
+			buf.WriteString(tok)
+		default:
+			if _, ok := sgrParams([]byte(tok)); ok {
+				buf.WriteString(tok)
+			}
+		}
+	})
+	return buf.String()
+}
+
+// inlineParams gives the SGR parameters of a foreground paint that can go
+// along others in a sequence: the standard colors in their `3x`/`9x` form,
+// without the `0;` of the constants which would reset the others.
+func inlineParams(p Paint) string {
+	if i, ok := p.index16(); ok {
+		return strconv.Itoa(30 + i%8 + i/8*60)
+	}
+	return string(p)
+}
+
+// PreviewAtLevel shows how s would look on a terminal of the given level,
+// to see how colored output degrades, i.e. for a `--show-color-degradation`
+// flag:
+//
+//	fmt.Println(color.PreviewAtLevel(banner, color.Level16))
+//
+// The paints beyond the level are replaced by the nearest color it has.
+// With 16 colors, the attributes Validate warns about, such as italic, are
+// dropped, and at LevelNone all the SGR sequences are.
+func PreviewAtLevel(s string, level Level) string {
+	var buf bytes.Buffer
+	eachToken(s, func(tok string, escape bool) {
+		seq, ok := "", false
+		if escape {
+			seq, ok = sgrParams([]byte(tok))
+		}
+		switch {
+		case !ok:
+			buf.WriteString(tok)
+			return
+		case level == LevelNone:
+			return
+		case isReset(tok) || level >= LevelTrueColor:
+			buf.WriteString(tok)
+			return
+		}
+
+		var kept []string
+		for _, param := range splitSGR(seq) {
+			switch n := param.n; {
+			case (n == 38 || n == 48) && param.paint != "":
+				params := inlineParams(param.paint.downsample(level))
+				if n == 48 {
+					params, _ = Paint(params).BackgroundCode()
+				}
+				kept = append(kept, params)
+			case level == Level16 && n >= 1 && n <= 9 && isBasicOnly(param):
+			default:
+				kept = append(kept, param.raw)
+			}
+		}
+		if len(kept) > 0 {
+			buf.WriteString(sgr(strings.Join(kept, ";")))
+		}
+	})
+	return buf.String()
+}
+
+// isBasicOnly tells if an SGR parameter sets one of the attributes
+// terminals limited to 16 colors often don't display.
+func isBasicOnly(param sgrParam) bool {
+	a := Attribute(strconv.Itoa(param.n))
+	if param.sub != "" {
+		a = Attribute(param.raw)
+	}
+	for _, b := range basicOnly {
+		if a == b {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package color
+
+import (
+	"reflect"
+	"testing"
+)
+
+var faintTT = []struct {
+	name string
+	s    string
+	want string
+}{
+	{"empty", "", ""},
+	{"plain", "text", "\033[2m" + "text" + "\033[0m"},
+	{"colored", Red("a"), "\033[1;31m" + "\033[2m" + "a" + "\033[0m"},
+	{"mixed", Red("a") + " b", "\033[1;31m" + "\033[2m" + "a" + "\033[0m" + "\033[2m" + " b" + "\033[0m"},
+	{"many colors", "\033[31ma\033[32mb\033[0m", "\033[31m\033[2ma\033[32m\033[2mb\033[0m"},
+}
+
+func TestFaint(t *testing.T) {
+	for _, test := range faintTT {
+		if got := Faint(test.s); got != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, got)
+		}
+	}
+}
+
+var optimizeTT = []struct {
+	name string
+	s    string
+	want string
+}{
+	{"plain", "text", "text"},
+	{"same style", Red("a") + Red("b"), "\033[1;31ma\033[1;31mb\033[0m"},
+	{"full reset", DarkRed("a") + DarkBlue("b"), "\033[0;31ma\033[0;34mb\033[0m"},
+	{"attributes overridden", "\033[1;31ma\033[0m\033[1;34mb\033[0m", "\033[1;31ma\033[1;34mb\033[0m"},
+	// the background would leak without the reset
+	{"background kept", NewBrush(BluePaint, RedPaint)("a") + Red("b"), NewBrush(BluePaint, RedPaint)("a") + Red("b")},
+	{"text after reset", Red("a") + " " + Red("b"), Red("a") + " " + Red("b")},
+}
+
+func TestOptimize(t *testing.T) {
+	for _, test := range optimizeTT {
+		got := Optimize(test.s)
+		if got != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, got)
+		}
+		if len(got) > len(test.s) {
+			t.Errorf("%s: optimized string is longer than the original", test.name)
+		}
+		if !reflect.DeepEqual(Parse(got), Parse(test.s)) {
+			t.Errorf("%s: optimized string doesn't look the same, want %#v, got %#v", test.name, Parse(test.s), Parse(got))
+		}
+	}
+}
+
+func TestOptimizeShrinks(t *testing.T) {
+	var s string
+	for i := 0; i < 10; i++ {
+		s += Green("ok")
+	}
+	if got := Optimize(s); len(got) != len(s)-9*len("\033[0m") {
+		t.Errorf("Want %d bytes, got %d", len(s)-9*len("\033[0m"), len(got))
+	}
+}
+
+var recolorTT = []struct {
+	name string
+	s    string
+	fg   Paint
+	want string
+}{
+	{"plain", "text", BluePaint, "text"},
+	{"many colors", "\033[31ma\033[32mb\033[0m", DarkBluePaint, "\033[34ma\033[34mb\033[0m"},
+	{"bright", Red("a") + DarkGreen("b"), BluePaint, "\033[1;94ma\033[0m\033[0;94mb\033[0m"},
+	{"background kept", NewBrush(BluePaint, RedPaint)("a"), DarkBluePaint, "\033[1;34m\033[44ma\033[0m"},
+	{"background in sequence", "\033[41;32;4ma\033[0m", Index(208), "\033[41;38;5;208;4ma\033[0m"},
+	{"extended", "\033[38;2;1;2;3;1ma\033[38;5;12mb\033[0m", DarkCyanPaint, "\033[36;1ma\033[36mb\033[0m"},
+	{"no foreground", "\033[44ma\033[0m", RedPaint, "\033[44ma\033[0m"},
+	{"removed", "\033[31ma\033[0m\033[31;1mb\033[0m", "", "a\033[0m\033[1mb\033[0m"},
+}
+
+func TestRecolor(t *testing.T) {
+	for _, test := range recolorTT {
+		if got := Recolor(test.s, test.fg); got != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestRecolorDisabled(t *testing.T) {
+	s := Red("a")
+	WithDisabled(func() {
+		if got := Recolor(s, BluePaint); got != s {
+			t.Errorf("Want %#v, got %#v", s, got)
+		}
+	})
+}
+
+var safeForPagerTT = []struct {
+	name string
+	s    string
+	want string
+}{
+	{"plain", "text", "text"},
+	{"colors kept", Red("a") + NewBrush(BluePaint, WhitePaint)("b"), Red("a") + NewBrush(BluePaint, WhitePaint)("b")},
+	{"cursor moves", "\033[2A" + Red("a") + "\033[10G" + "b", Red("a") + "b"},
+	{"clears", "\r\033[2K" + Red("50%") + "\033[K", "\r" + Red("50%")},
+	{"hyperlink", "\033]8;;https://example.com\033\\" + Red("link") + "\033]8;;\033\\", Red("link")},
+	{"other escapes", "\0337a\0338", "a"},
+	{"truncated", Red("a") + "\033[3", Red("a")},
+}
+
+func TestSafeForPager(t *testing.T) {
+	for _, test := range safeForPagerTT {
+		if got := SafeForPager(test.s); got != test.want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestPreviewAtLevel(t *testing.T) {
+	s := NewStyle("", PaintRGB(250, 10, 10)).WithAttributes(Italic).Brush()("hot") + " " +
+		Background(PaintRGB(0, 0, 200)).Brush()("cold")
+
+	for _, test := range []struct {
+		level Level
+		want  string
+	}{
+		{LevelTrueColor, s},
+		{Level256, "\033[38;5;196;3mhot\033[0m \033[48;5;20mcold\033[0m"},
+		{Level16, "\033[91mhot\033[0m \033[44mcold\033[0m"},
+		{LevelNone, "hot cold"},
+	} {
+		if got := PreviewAtLevel(s, test.level); got != test.want {
+			t.Errorf("level %d: Want %#v, got %#v", test.level, test.want, got)
+		}
+	}
+}
+
+func TestPreviewAtLevelAttributes(t *testing.T) {
+	s := "\033[1;3;4:3mx\033[0m\033[9my\033[0m"
+	want := "\033[1mx\033[0my\033[0m"
+	if got := PreviewAtLevel(s, Level16); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
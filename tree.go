@@ -0,0 +1,61 @@
+package color
+
+import (
+	"bytes"
+)
+
+// Node is an entry of a tree drawn by Tree, with the entries under it.
+type Node struct {
+	Label    string
+	Children []Node
+}
+
+// TreeOptions are the brushes used to draw trees.  A nil Brush leaves its
+// part plain.
+type TreeOptions struct {
+	// Guides paints the branches, `├── `, `└── ` and `│   `.
+	Guides Brush
+	// Label paints the labels of the nodes.
+	Label Brush
+}
+
+// DefaultTreeOptions draws the branches in dark gray and leaves the labels
+// plain.  It is used by Tree.
+var DefaultTreeOptions = TreeOptions{
+	Guides: DarkGray,
+}
+
+// Tree draws the tree under root, one node per line, with the branches
+// painted according to DefaultTreeOptions, like the `tree` command:
+//
+//	app
+//	├── cmd
+//	│   └── main.go
+//	└── go.mod
+func Tree(root Node) string {
+	return DefaultTreeOptions.Tree(root)
+}
+
+// Tree draws the tree under root, one node per line.  Each line ends with a
+// newline.
+func (o TreeOptions) Tree(root Node) string {
+	var buf bytes.Buffer
+	buf.WriteString(o.Label.paint(root.Label))
+	buf.WriteByte('\n')
+	o.writeChildren(&buf, root.Children, "")
+	return buf.String()
+}
+
+// writeChildren draws nodes under a parent whose own guides are prefix.
+func (o TreeOptions) writeChildren(buf *bytes.Buffer, nodes []Node, prefix string) {
+	for i, node := range nodes {
+		branch, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, next = "└── ", "    "
+		}
+		buf.WriteString(o.Guides.paint(prefix + branch))
+		buf.WriteString(o.Label.paint(node.Label))
+		buf.WriteByte('\n')
+		o.writeChildren(buf, node.Children, prefix+next)
+	}
+}
@@ -0,0 +1,40 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	root := Node{Label: "app", Children: []Node{
+		{Label: "cmd", Children: []Node{
+			{Label: "main.go"},
+			{Label: "internal", Children: []Node{{Label: "deep.go"}}},
+		}},
+		{Label: "go.mod"},
+	}}
+	g := DarkGray
+
+	want := "app\n" +
+		g("├── ") + "cmd\n" +
+		g("│   ├── ") + "main.go\n" +
+		g("│   └── ") + "internal\n" +
+		g("│       └── ") + "deep.go\n" +
+		g("└── ") + "go.mod\n"
+	if got := Tree(root); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
+
+func TestTreeOptions(t *testing.T) {
+	opts := TreeOptions{Label: Blue}
+	root := Node{Label: "a", Children: []Node{{Label: "b"}}}
+
+	want := Blue("a") + "\n" + "└── " + Blue("b") + "\n"
+	if got := opts.Tree(root); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+
+	if want, got := Blue("leaf")+"\n", opts.Tree(Node{Label: "leaf"}); got != want {
+		t.Errorf("Want %#v, got %#v", want, got)
+	}
+}
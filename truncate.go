@@ -0,0 +1,90 @@
+package color
+
+// ellipsis marks where text was cut.
+const ellipsis = "…"
+
+// Truncate cuts s to width visible runes, ending it with an ellipsis if it
+// is longer.  Escape sequences are kept, and a style left open by the cut is
+// reset before the ellipsis.
+func Truncate(s string, width int) string {
+	if VisibleLen(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	head, _, open := splitVisible(s, width-1)
+	return head + resetIfOpen(open) + ellipsis
+}
+
+// TruncateMiddle cuts s to width visible runes by replacing its middle with
+// an ellipsis, keeping its start and its end, which is handy for long
+// paths, i.e:
+//
+//	color.TruncateMiddle(color.Blue("/home/dev/projects/app/main.go"), 16) // "/home/d…/main.go" in blue
+//
+// The style left open before the ellipsis is reset, and the one active
+// where the end starts is set again after it.
+func TruncateMiddle(s string, width int) string {
+	n := VisibleLen(s)
+	if n <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	// the end gets the extra rune, it's where file names are
+	keep := width - 1
+	headLen := keep / 2
+	head, _, headOpen := splitVisible(s, headLen)
+	_, tail, tailOpen := splitVisible(s, n-(keep-headLen))
+	return head + resetIfOpen(headOpen) + ellipsis + tailOpen + tail
+}
+
+// splitVisible splits s after its n first visible runes, the escape
+// sequences right after them going with the tail.  open gives the SGR
+// sequences of the head still in effect where the tail starts, the ones
+// after its last reset.
+func splitVisible(s string, n int) (head, tail, open string) {
+	var style Style
+	pos, count, done := 0, 0, false
+	eachToken(s, func(tok string, escape bool) {
+		switch {
+		case done:
+			return
+		case escape && count >= n:
+			done = true
+			return
+		case escape:
+			if params, ok := sgrParams([]byte(tok)); ok {
+				style = applySGR(style, params)
+				if style == (Style{}) {
+					open = ""
+				} else {
+					open += tok
+				}
+			}
+			pos += len(tok)
+			return
+		}
+		for i := range tok {
+			if count == n {
+				pos += i
+				done = true
+				return
+			}
+			count++
+		}
+		pos += len(tok)
+	})
+	return s[:pos], s[pos:], open
+}
+
+func resetIfOpen(open string) string {
+	if open == "" {
+		return ""
+	}
+	return ResetCode()
+}
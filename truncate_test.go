@@ -0,0 +1,57 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	blue := NewStyle("", BluePaint)
+
+	for _, test := range []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"too long", 5, "too …"},
+		{"too long", 1, "…"},
+		{"too long", 0, ""},
+		{blue.Brush()("colored"), 10, blue.Brush()("colored")},
+		{blue.Brush()("colored"), 4, blue.code + "col" + "\033[0m" + "…"},
+		{"ab" + blue.Brush()("cdef"), 3, "ab…"},
+		{"日本語テキスト", 4, "日本語…"},
+	} {
+		if got := Truncate(test.s, test.width); got != test.want {
+			t.Errorf("Truncate(%q, %d): Want %#v, got %#v", test.s, test.width, test.want, got)
+		}
+	}
+}
+
+func TestTruncateMiddle(t *testing.T) {
+	dir, file := NewStyle("", DarkBluePaint), NewStyle("", YellowPaint)
+	path := dir.Brush()("/home/dev/projects/app/") + file.Brush()("main.go")
+
+	for _, test := range []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"/usr/bin", 20, "/usr/bin"},
+		{"/home/dev/projects/app/main.go", 16, "/home/d…/main.go"},
+		{"/home/dev/projects/app/main.go", 1, "…"},
+		{"/home/dev/projects/app/main.go", 0, ""},
+		// the cut lands inside the directory and the file name
+		{path, 9, dir.code + "/hom" + "\033[0m" + "…" + file.code + "n.go" + "\033[0m"},
+		{path, 10, dir.code + "/hom" + "\033[0m" + "…" + file.code + "in.go" + "\033[0m"},
+		// the end starts inside the directory
+		{path, 16, dir.code + "/home/d" + "\033[0m" + "…" + dir.code + "/" + "\033[0m" + file.Brush()("main.go")},
+	} {
+		if got := TruncateMiddle(test.s, test.width); got != test.want {
+			t.Errorf("TruncateMiddle(%q, %d): Want %#v, got %#v", test.s, test.width, test.want, got)
+		}
+		if n := VisibleLen(TruncateMiddle(test.s, test.width)); n > test.width {
+			t.Errorf("TruncateMiddle(%q, %d): want at most %d runes, got %d", test.s, test.width, test.width, n)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+// +build !windows
+
+package color
+
+// EnableWindowsVirtualTerminal makes the Windows console interpret the
+// escape sequences of the brushes, disabling colors if it can't.  It does
+// nothing and returns nil on other systems, whose terminals already do.
+func EnableWindowsVirtualTerminal() error {
+	return nil
+}
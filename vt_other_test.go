@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package color
+
+import "testing"
+
+func TestEnableWindowsVirtualTerminal(t *testing.T) {
+	if err := EnableWindowsVirtualTerminal(); err != nil {
+		t.Errorf("Want no error, got %v", err)
+	}
+	if !Enabled() {
+		t.Errorf("Want colors left enabled")
+	}
+}
@@ -0,0 +1,46 @@
+//go:build windows
+// +build windows
+
+package color
+
+import "syscall"
+
+// enableVirtualTerminalProcessing is the console mode making the Windows
+// console interpret escape sequences.
+const enableVirtualTerminalProcessing = 0x0004
+
+var procSetConsoleMode = syscall.NewLazyDLL("kernel32.dll").NewProc("SetConsoleMode")
+
+// EnableWindowsVirtualTerminal makes the Windows console interpret the
+// escape sequences of the brushes, as it does since Windows 10, instead of
+// printing them as garbage.  Call it once at startup:
+//
+//	func main() {
+//		if err := color.EnableWindowsVirtualTerminal(); err != nil {
+//			log.Printf("no colors: %v", err)
+//		}
+//	}
+//
+// It turns on virtual terminal processing for the consoles behind stdout
+// and stderr, and leaves alone the ones that are redirected.  If a console
+// can't be put in that mode, such as on older Windows, colors are disabled
+// so that brushes give plain text, and the error is returned.  It does
+// nothing and returns nil on other systems.
+func EnableWindowsVirtualTerminal() error {
+	for _, h := range []syscall.Handle{syscall.Stdout, syscall.Stderr} {
+		var mode uint32
+		if err := syscall.GetConsoleMode(h, &mode); err != nil {
+			// not a console
+			continue
+		}
+		if mode&enableVirtualTerminalProcessing != 0 {
+			continue
+		}
+		ok, _, err := procSetConsoleMode.Call(uintptr(h), uintptr(mode|enableVirtualTerminalProcessing))
+		if ok == 0 {
+			Disable()
+			return err
+		}
+	}
+	return nil
+}
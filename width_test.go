@@ -0,0 +1,25 @@
+package color
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"plain", 5},
+		{Red("error"), 5},
+		{"日本語", 6},
+		{Cyan("表") + "x", 3},
+		{"e\u0301te\u0301", 3},
+		{"a\u200db", 2},
+		{"✓ ✅", 4},
+		{"ｆｕｌｌ", 8},
+		{"cut " + Red("x") + "\033[3", 5},
+	} {
+		if got := DisplayWidth(test.s); got != test.want {
+			t.Errorf("%q: Want %d, got %d", test.s, test.want, got)
+		}
+	}
+}
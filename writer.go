@@ -0,0 +1,158 @@
+package color
+
+import (
+	"bytes"
+	"io"
+	"time"
+)
+
+// TimestampLayout is the layout used by the timestamp writers to format the
+// time at the beginning of each line, the same as log.LstdFlags.
+const TimestampLayout = "2006/01/02 15:04:05"
+
+type timestampWriter struct {
+	w         io.Writer
+	brush     Brush
+	now       func() time.Time
+	lineStart bool
+}
+
+// NewTimestampWriter gives you a writer that forwards everything to w,
+// prefixing each line with the current time painted with s.  Lines can be
+// split across many calls to Write, in which case only the first part of the
+// line gets a timestamp.
+func NewTimestampWriter(w io.Writer, s Style) io.Writer {
+	return &timestampWriter{
+		w:         w,
+		brush:     s.Brush(),
+		now:       time.Now,
+		lineStart: true,
+	}
+}
+
+func (t *timestampWriter) Write(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if t.lineStart {
+			prefix := t.brush(t.now().Format(TimestampLayout)) + " "
+			if _, err := io.WriteString(t.w, prefix); err != nil {
+				return n, err
+			}
+			t.lineStart = false
+		}
+
+		line := p[n:]
+		if i := bytes.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i+1]
+			t.lineStart = true
+		}
+
+		written, err := t.w.Write(line)
+		n += written
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+type styleWriter struct {
+	w     io.Writer
+	style Style
+}
+
+// Writer gives you a writer that forwards everything to w painted with the
+// style, wrapping each write in the style's code and a reset, i.e. for the
+// output of a library that takes a writer:
+//
+//	cmd.Stderr = NewStyle("", RedPaint).Writer(os.Stderr)
+//
+// Like any writer, it reports the bytes of p that were written, not the
+// bytes of the codes.  Empty writes are forwarded without codes.
+func (s Style) Writer(w io.Writer) io.Writer {
+	return &styleWriter{w: w, style: s}
+}
+
+func (s *styleWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 || !Enabled() || s.style.code == "" {
+		return s.w.Write(p)
+	}
+
+	code, reset := s.style.code, s.style.reset()
+	buf := make([]byte, 0, len(code)+len(p)+len(reset))
+	buf = append(append(append(buf, code...), p...), reset...)
+	written, err := s.w.Write(buf)
+	if err == nil && written < len(buf) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		// only count the bytes of p past the code
+		n := written - len(code)
+		if n < 0 {
+			n = 0
+		} else if n > len(p) {
+			n = len(p)
+		}
+		return n, err
+	}
+	return len(p), nil
+}
+
+// CopyPreservingColor copies src to dst like io.Copy, except that escape
+// sequences split across reads are held back until they're whole, so that
+// each write to dst has only whole sequences.  A sequence still unfinished
+// after maxHeldLen bytes is taken for text and copied as it comes.  It
+// returns the number of bytes written.
+func CopyPreservingColor(dst io.Writer, src io.Reader) (written int64, err error) {
+	buf := make([]byte, 32*1024)
+	var pending []byte
+	for {
+		n, rerr := src.Read(buf)
+		pending = append(pending, buf[:n]...)
+
+		whole := pending
+		if l := wholeLen(pending); rerr == nil && len(pending)-l <= maxHeldLen {
+			whole = pending[:l]
+		}
+		if len(whole) > 0 {
+			w, werr := dst.Write(whole)
+			written += int64(w)
+			if werr == nil && w < len(whole) {
+				werr = io.ErrShortWrite
+			}
+			if werr != nil {
+				return written, werr
+			}
+			pending = append(pending[:0], pending[len(whole):]...)
+		}
+
+		switch {
+		case rerr == io.EOF:
+			return written, nil
+		case rerr != nil:
+			return written, rerr
+		}
+	}
+}
+
+// maxHeldLen is the longest sequence CopyPreservingColor holds back, room
+// enough for the hyperlinks with long URLs.
+const maxHeldLen = 4096
+
+// wholeLen gives the length of b up to the escape sequence or the rune it
+// ends with, if it isn't whole.
+func wholeLen(b []byte) int {
+	for i := 0; i < len(b); {
+		j := indexIntroducer(b[i:])
+		if j < 0 {
+			return len(b) - partialRuneLen(b)
+		}
+		i += j
+		n, ok := escapeLen(b[i:])
+		if !ok {
+			return i
+		}
+		i += n
+	}
+	return len(b)
+}
@@ -0,0 +1,171 @@
+package color
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestTimestampWriter(buf *bytes.Buffer, s Style) *timestampWriter {
+	tw := NewTimestampWriter(buf, s).(*timestampWriter)
+	tw.now = func() time.Time {
+		return time.Date(2014, time.July, 13, 12, 30, 0, 0, time.UTC)
+	}
+	return tw
+}
+
+var timestampTT = []struct {
+	name   string
+	writes []string
+	want   string
+}{
+	{"empty", []string{""}, ""},
+	{"one line", []string{"hello\n"}, "{ts} hello\n"},
+	{"many lines", []string{"hello\nworld\n"}, "{ts} hello\n{ts} world\n"},
+	{"partial line", []string{"hel", "lo\nwor", "ld\n"}, "{ts} hello\n{ts} world\n"},
+	{"no trailing newline", []string{"hello\nworld"}, "{ts} hello\n{ts} world"},
+	{"blank lines", []string{"\n", "\n"}, "{ts} \n{ts} \n"},
+}
+
+func TestTimestampWriter(t *testing.T) {
+	s := NewStyle("", DarkGrayPaint)
+	ts := s.Brush()("2014/07/13 12:30:00")
+
+	for _, test := range timestampTT {
+		buf := bytes.NewBuffer(nil)
+		tw := newTestTimestampWriter(buf, s)
+
+		for _, w := range test.writes {
+			n, err := tw.Write([]byte(w))
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", test.name, err)
+			}
+			if n != len(w) {
+				t.Errorf("%s: want n=%d, got %d", test.name, len(w), n)
+			}
+		}
+
+		want := strings.Replace(test.want, "{ts}", ts, -1)
+		if got := buf.String(); got != want {
+			t.Errorf("%s: Want %#v, got %#v", test.name, want, got)
+		}
+	}
+}
+
+// chunkReader reads its chunks one at a time.
+type chunkReader struct {
+	chunks []string
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+	return n, nil
+}
+
+// writesRecorder records each write it gets.
+type writesRecorder struct {
+	writes []string
+}
+
+func (w *writesRecorder) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+var copyPreservingColorTT = []struct {
+	name   string
+	chunks []string
+	writes []string
+}{
+	{"whole", []string{"a\033[31mb", "c\033[0m"}, []string{"a\033[31mb", "c\033[0m"}},
+	{"split csi", []string{"a\033[3", "1mb\033[0m"}, []string{"a", "\033[31mb\033[0m"}},
+	{"split after escape", []string{"a\033", "[31mb"}, []string{"a", "\033[31mb"}},
+	{"split many times", []string{"\033", "[", "1;3", "1m", "x"}, []string{"\033[1;31m", "x"}},
+	{"split osc", []string{"\033]0;ti", "tle\033", "\\x"}, []string{"\033]0;title\033\\x"}},
+	{"unfinished at the end", []string{"a\033[3"}, []string{"a", "\033[3"}},
+	{"never finished", []string{"\033]8;;" + strings.Repeat("x", maxHeldLen), "y"},
+		[]string{"\033]8;;" + strings.Repeat("x", maxHeldLen), "y"}},
+}
+
+func TestCopyPreservingColor(t *testing.T) {
+	for _, test := range copyPreservingColorTT {
+		var w writesRecorder
+		n, err := CopyPreservingColor(&w, &chunkReader{test.chunks})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(w.writes, test.writes) {
+			t.Errorf("%s: Want %#v, got %#v", test.name, test.writes, w.writes)
+		}
+		if want := int64(len(strings.Join(test.chunks, ""))); n != want {
+			t.Errorf("%s: want %d bytes written, got %d", test.name, want, n)
+		}
+	}
+}
+
+// shortWriter writes at most n bytes, and fails once they're written.
+type shortWriter struct {
+	bytes.Buffer
+	n int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		p = p[:w.n]
+	}
+	w.n -= len(p)
+	w.Buffer.Write(p)
+	if w.n == 0 {
+		return len(p), io.ErrClosedPipe
+	}
+	return len(p), nil
+}
+
+func TestStyleWriter(t *testing.T) {
+	style := NewStyle("", RedPaint)
+	var rec writesRecorder
+	w := style.Writer(&rec)
+
+	for _, p := range []string{"error", "", "again\n"} {
+		if n, err := w.Write([]byte(p)); n != len(p) || err != nil {
+			t.Errorf("%q: Want (%d, <nil>), got (%d, %v)", p, len(p), n, err)
+		}
+	}
+	want := []string{Red("error"), "", Red("again\n")}
+	if !reflect.DeepEqual(rec.writes, want) {
+		t.Errorf("Want %#v, got %#v", want, rec.writes)
+	}
+
+	Disable()
+	rec.writes = nil
+	w.Write([]byte("plain"))
+	Enable()
+	if want := []string{"plain"}; !reflect.DeepEqual(rec.writes, want) {
+		t.Errorf("Want %#v, got %#v", want, rec.writes)
+	}
+}
+
+func TestStyleWriterShortWrite(t *testing.T) {
+	style := NewStyle("", RedPaint)
+	for _, test := range []struct {
+		limit, n int
+	}{
+		{3, 0},  // within the code
+		{9, 2},  // within the text
+		{15, 5}, // within the reset
+	} {
+		sw := &shortWriter{n: test.limit}
+		n, err := style.Writer(sw).Write([]byte("error"))
+		if n != test.n || err != io.ErrClosedPipe {
+			t.Errorf("%d bytes: Want (%d, %v), got (%d, %v)", test.limit, test.n, io.ErrClosedPipe, n, err)
+		}
+	}
+}